@@ -0,0 +1,729 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+/*
+Aggregator interface is used to calculate an aggregate value (sum, mean, max...) of a set of
+DataFrame rows. The aggregators can be merged, so a set of rows can be split in several
+parts, aggregated separately and, at last, merged. It is the base of the parallel and
+grouped executions.
+
+The aggregators are used with the DataFrame methods Aggregate, AggregateRange,
+AggregateParallel and AggregateParallelRange.
+
+Example:
+	value, err := df.Aggregate(NewMeanAggregator("price"))
+	mean, _ := value.Float64()
+*/
+type Aggregator interface {
+	// Init prepares the aggregator to process the rows of the df DataFrame. It returns an
+	// error if the aggregator can't process the DataFrame (column not found, invalid type...)
+	Init(df *DataFrame) error
+	// Step processes the row.
+	Step(row Row) error
+	// Merge merges the partial result of the other aggregator in the aggregator. other is
+	// always an aggregator returned by New and initialized with the same DataFrame.
+	Merge(other Aggregator) error
+	// Result returns the aggregate value. It returns a null value if the aggregate value
+	// can't be calculated (for example, the mean of 0 values).
+	Result() Value
+	// New returns a new aggregator, with the same configuration and an empty result.
+	New() Aggregator
+}
+
+// errNotMergeable is the error returned when the aggregators have different types.
+var errNotMergeable = errors.New("the aggregators can't be merged")
+
+// aggregatorBase is the base struct of the built-in aggregators of a column.
+type aggregatorBase struct {
+	colName string     // Column name
+	ctype   columnType // Column type
+}
+
+// initColumn checks if the column exists in df and if it has one of the valid types.
+// The name param is the name of the operation used in the error message.
+func (a *aggregatorBase) initColumn(df *DataFrame, name string, valid ...columnType) error {
+	col, exists := df.getColumnByName(a.colName)
+	if !exists {
+		return fmt.Errorf("column %s not found", a.colName)
+	}
+
+	for _, ctype := range valid {
+		if col.ctype == ctype {
+			a.ctype = ctype
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s operation is invalid in column type %s", name, col.ctype)
+}
+
+// sumAggregator aggregator sums the values of a column type int, uint, float or complex.
+type sumAggregator struct {
+	aggregatorBase
+	opts SumOptions
+	i    int64
+	u    uint64
+	f    float64
+	c    complex128
+	// compensations of the float and complex sums.
+	fc float64
+	cc complex128
+	// exact sum of the integers, used after an overflow with the SUM_OVERFLOW_FLOAT policy.
+	big *big.Int
+	// exact sum of the decimals.
+	dec *decimalSum
+}
+
+// NewSumAggregator returns an aggregator that sums the values of the colName column.
+// The column must be type int, uint, float, complex or decimal, and the result has the same
+// type. The decimals are summed exactly, with the max scale of the values. The null values are
+// ignored. If the sum of the integers or the decimals overflows, it returns an error.
+func NewSumAggregator(colName string) Aggregator {
+	return NewSumAggregatorWithOptions(colName, SumOptions{})
+}
+
+// NewSumAggregatorWithOptions returns an aggregator that sums the values of the colName
+// column, using the opts options to handle the integer overflows and the float rounding
+// errors. See SumOptions.
+func NewSumAggregatorWithOptions(colName string, opts SumOptions) Aggregator {
+	return &sumAggregator{aggregatorBase: aggregatorBase{colName: colName}, opts: opts}
+}
+
+// Init checks the column and the options of the aggregator.
+func (a *sumAggregator) Init(df *DataFrame) error {
+	if err := a.opts.check(); err != nil {
+		return err
+	}
+
+	return a.initColumn(df, "Sum", INT, UINT, FLOAT, COMPLEX, DECIMAL)
+}
+
+// promote moves the integer sum to the exact sum, after an overflow.
+func (a *sumAggregator) promote() {
+	if a.big != nil {
+		return
+	}
+
+	if a.ctype == INT {
+		a.big = new(big.Int).SetInt64(a.i)
+	} else {
+		a.big = new(big.Int).SetUint64(a.u)
+	}
+}
+
+// overflow applies the overflow policy when the integer sum overflows. It returns true
+// whether the sum must continue in the exact sum.
+func (a *sumAggregator) overflow() (bool, error) {
+	switch a.opts.Overflow {
+	case SUM_OVERFLOW_FLOAT:
+		a.promote()
+		return true, nil
+	case SUM_OVERFLOW_WRAP:
+		return false, nil
+	default:
+		return false, fmt.Errorf("the sum of the column %s overflows the type %s",
+			a.colName, a.ctype)
+	}
+}
+
+// addInt adds the i integer to the sum.
+func (a *sumAggregator) addInt(i int64) error {
+	if a.big == nil {
+		sum, overflow := addInt64(a.i, i)
+		if !overflow {
+			a.i = sum
+			return nil
+		}
+
+		promoted, err := a.overflow()
+		if err != nil {
+			return err
+		} else if !promoted {
+			a.i = sum
+			return nil
+		}
+	}
+
+	a.big.Add(a.big, big.NewInt(i))
+	return nil
+}
+
+// addUint adds the u integer to the sum.
+func (a *sumAggregator) addUint(u uint64) error {
+	if a.big == nil {
+		sum, carry := bits.Add64(a.u, u, 0)
+		if carry == 0 {
+			a.u = sum
+			return nil
+		}
+
+		promoted, err := a.overflow()
+		if err != nil {
+			return err
+		} else if !promoted {
+			a.u = sum
+			return nil
+		}
+	}
+
+	a.big.Add(a.big, new(big.Int).SetUint64(u))
+	return nil
+}
+
+// addDecimal adds the d decimal to the sum. The decimal sum only continues out of the decimal
+// range with the SUM_OVERFLOW_FLOAT policy.
+func (a *sumAggregator) addDecimal(d Decimal) error {
+	if a.dec == nil {
+		a.dec = newDecimalSum()
+	}
+
+	a.dec.add(d)
+	if _, err := a.dec.result(); err != nil && a.opts.Overflow != SUM_OVERFLOW_FLOAT {
+		return fmt.Errorf("the sum of the column %s overflows the type %s", a.colName, a.ctype)
+	}
+
+	return nil
+}
+
+// addFloat adds the f float to the sum.
+func (a *sumAggregator) addFloat(f float64) {
+	if a.opts.Compensated {
+		a.f, a.fc = neumaierAdd(a.f, a.fc, f)
+	} else {
+		a.f += f
+	}
+}
+
+// addComplex adds the c complex to the sum.
+func (a *sumAggregator) addComplex(c complex128) {
+	if !a.opts.Compensated {
+		a.c += c
+		return
+	}
+
+	re, rec := neumaierAdd(real(a.c), real(a.cc), real(c))
+	im, imc := neumaierAdd(imag(a.c), imag(a.cc), imag(c))
+	a.c, a.cc = complex(re, im), complex(rec, imc)
+}
+
+// Step sums the value of the row.
+func (a *sumAggregator) Step(row Row) error {
+	v, err := row.Cell(a.colName)
+	if err != nil || v.IsNull() {
+		return err
+	}
+
+	switch a.ctype {
+	case INT:
+		i, _ := v.Int64()
+		return a.addInt(i)
+	case UINT:
+		u, _ := v.Uint64()
+		return a.addUint(u)
+	case FLOAT:
+		f, _ := v.Float64()
+		a.addFloat(f)
+	case COMPLEX:
+		c, _ := v.Complex128()
+		a.addComplex(c)
+	case DECIMAL:
+		d, _ := v.Decimal()
+		return a.addDecimal(d)
+	}
+
+	return nil
+}
+
+// Merge sums the result of other aggregator.
+func (a *sumAggregator) Merge(other Aggregator) error {
+	o, ok := other.(*sumAggregator)
+	if !ok {
+		return errNotMergeable
+	}
+
+	var err error
+	switch {
+	case o.dec != nil:
+		if a.dec == nil {
+			a.dec = newDecimalSum()
+		}
+
+		a.dec.merge(o.dec)
+		if _, err := a.dec.result(); err != nil && a.opts.Overflow != SUM_OVERFLOW_FLOAT {
+			return fmt.Errorf("the sum of the column %s overflows the type %s",
+				a.colName, a.ctype)
+		}
+	case o.big != nil:
+		a.promote()
+		a.big.Add(a.big, o.big)
+	case a.ctype == INT:
+		err = a.addInt(o.i)
+	case a.ctype == UINT:
+		err = a.addUint(o.u)
+	}
+
+	a.addFloat(o.f)
+	a.addFloat(o.fc)
+	a.addComplex(o.c)
+	a.addComplex(o.cc)
+	return err
+}
+
+// Result returns the sum. If the integer or decimal sum has overflowed with the
+// SUM_OVERFLOW_FLOAT policy, the result is a float.
+func (a *sumAggregator) Result() Value {
+	if a.ctype == DECIMAL {
+		if a.dec == nil {
+			return newDecimalValue(Decimal{})
+		}
+
+		if d, err := a.dec.result(); err == nil {
+			return newDecimalValue(d)
+		}
+
+		f, _ := new(big.Rat).SetFrac(a.dec.sum, pow10(a.dec.scale)).Float64()
+		return newFloatValue(f)
+	}
+
+	if a.big != nil {
+		if a.ctype == INT && a.big.IsInt64() {
+			return newIntValue(a.big.Int64())
+		} else if a.ctype == UINT && a.big.IsUint64() {
+			return newUintValue(a.big.Uint64())
+		}
+
+		f, _ := new(big.Float).SetInt(a.big).Float64()
+		return newFloatValue(f)
+	}
+
+	switch a.ctype {
+	case INT:
+		return newIntValue(a.i)
+	case UINT:
+		return newUintValue(a.u)
+	case FLOAT:
+		return newFloatValue(a.f + a.fc)
+	case COMPLEX:
+		return newComplexValue(a.c + a.cc)
+	default:
+		return Value{}
+	}
+}
+
+// New returns a new sum aggregator of the same column.
+func (a *sumAggregator) New() Aggregator {
+	return NewSumAggregatorWithOptions(a.colName, a.opts)
+}
+
+// countAggregator aggregator counts the rows or the not null values of a column.
+type countAggregator struct {
+	aggregatorBase
+	count int64
+}
+
+// NewCountAggregator returns an aggregator that counts the not null values of the colName
+// column. If colName is empty, it counts all rows. The result is type int.
+func NewCountAggregator(colName string) Aggregator {
+	return &countAggregator{aggregatorBase: aggregatorBase{colName: colName}}
+}
+
+// Init checks the column of the aggregator.
+func (a *countAggregator) Init(df *DataFrame) error {
+	if a.colName == "" {
+		return nil
+	}
+
+	return a.initColumn(df, "Count", INT, UINT, FLOAT, COMPLEX, STRING, CATEGORY, DECIMAL)
+}
+
+// Step counts the row.
+func (a *countAggregator) Step(row Row) error {
+	if a.colName != "" {
+		v, err := row.Cell(a.colName)
+		if err != nil || v.IsNull() {
+			return err
+		}
+	}
+
+	a.count++
+	return nil
+}
+
+// Merge adds the count of other aggregator.
+func (a *countAggregator) Merge(other Aggregator) error {
+	o, ok := other.(*countAggregator)
+	if !ok {
+		return errNotMergeable
+	}
+
+	a.count += o.count
+	return nil
+}
+
+// Result returns the count.
+func (a *countAggregator) Result() Value {
+	return newIntValue(a.count)
+}
+
+// New returns a new count aggregator of the same column.
+func (a *countAggregator) New() Aggregator {
+	return NewCountAggregator(a.colName)
+}
+
+// minMaxAggregator aggregator calculates the min or the max of a column.
+type minMaxAggregator struct {
+	aggregatorBase
+	// the value replaces the current value when the comparison returns cvalue.
+	cvalue Comparers
+	// function to compare the values.
+	compare func(a, b Value) (Comparers, error)
+	// current value.
+	value Value
+}
+
+// NewMinAggregator returns an aggregator that calculates the min of the colName column,
+// using the Compare function of the column type. The result is the min value.
+// The null values and the NaN floats are ignored. If there aren't values, the result is null.
+func NewMinAggregator(colName string) Aggregator {
+	return &minMaxAggregator{aggregatorBase: aggregatorBase{colName: colName}, cvalue: LESS}
+}
+
+// NewMaxAggregator returns an aggregator that calculates the max of the colName column,
+// using the Compare function of the column type. The result is the max value.
+// The null values and the NaN floats are ignored. If there aren't values, the result is null.
+func NewMaxAggregator(colName string) Aggregator {
+	return &minMaxAggregator{aggregatorBase: aggregatorBase{colName: colName}, cvalue: GREAT}
+}
+
+// Init checks the column of the aggregator.
+func (a *minMaxAggregator) Init(df *DataFrame) error {
+	name := "Min"
+	if a.cvalue == GREAT {
+		name = "Max"
+	}
+
+	err := a.initColumn(df, name, INT, UINT, FLOAT, COMPLEX, STRING, CATEGORY, DECIMAL)
+	if err != nil {
+		return err
+	}
+
+	a.compare = columnComparer(a.ctype)
+	return nil
+}
+
+// update replaces the current value by v, whether v is less or great than it.
+func (a *minMaxAggregator) update(v Value) {
+	if v.IsNull() || isNaNValue(v) {
+		return
+	}
+
+	if a.value.IsNull() {
+		a.value = v
+		return
+	}
+
+	if comp, _ := a.compare(v, a.value); comp == a.cvalue {
+		a.value = v
+	}
+}
+
+// Step compares the value of the row with the current value.
+func (a *minMaxAggregator) Step(row Row) error {
+	v, err := row.Cell(a.colName)
+	if err != nil {
+		return err
+	}
+
+	a.update(v)
+	return nil
+}
+
+// Merge compares the result of other aggregator with the current value.
+func (a *minMaxAggregator) Merge(other Aggregator) error {
+	o, ok := other.(*minMaxAggregator)
+	if !ok || o.cvalue != a.cvalue {
+		return errNotMergeable
+	}
+
+	a.update(o.value)
+	return nil
+}
+
+// Result returns the min or max value.
+func (a *minMaxAggregator) Result() Value {
+	return a.value
+}
+
+// New returns a new aggregator of the same column.
+func (a *minMaxAggregator) New() Aggregator {
+	return &minMaxAggregator{aggregatorBase: aggregatorBase{colName: a.colName}, cvalue: a.cvalue}
+}
+
+// meanAggregator aggregator calculates the arithmetic mean, the variance and the standard
+// deviation of a column. It uses the Welford algorithm.
+type meanAggregator struct {
+	aggregatorBase
+	// name of the operation: Mean, Variance or Std.
+	name string
+	// number of values.
+	count float64
+	// current mean.
+	mean float64
+	// sum of the squares of the differences to the mean.
+	m2 float64
+	// exact flag calculates the exact mean of a decimal column, using the dec sum. The
+	// result is a decimal rounded with the rounding mode.
+	exact    bool
+	dec      *decimalSum
+	rounding roundingMode
+}
+
+// NewMeanAggregator returns an aggregator that calculates the arithmetic mean of the
+// colName column. The column must be type int, uint, float or decimal and the result is type
+// float, also in the decimal columns, so the mean of a decimal column can lose precision.
+// The null values are ignored. If there aren't values, the result is null. The exact mean
+// of the decimal columns, type decimal, is calculated by MeanDecimal.
+func NewMeanAggregator(colName string) Aggregator {
+	return &meanAggregator{aggregatorBase: aggregatorBase{colName: colName}, name: "Mean"}
+}
+
+// NewVarianceAggregator returns an aggregator that calculates the sample variance of the
+// colName column. The column must be type int, uint, float or decimal and the result is type
+// float. The null values are ignored. If there are less than 2 values, the result is null.
+func NewVarianceAggregator(colName string) Aggregator {
+	return &meanAggregator{aggregatorBase: aggregatorBase{colName: colName}, name: "Variance"}
+}
+
+// NewStdAggregator returns an aggregator that calculates the sample standard deviation of
+// the colName column. The column must be type int, uint, float or decimal and the result is
+// type float. The null values are ignored. If there are less than 2 values, the result is null.
+func NewStdAggregator(colName string) Aggregator {
+	return &meanAggregator{aggregatorBase: aggregatorBase{colName: colName}, name: "Std"}
+}
+
+// Init checks the column of the aggregator.
+func (a *meanAggregator) Init(df *DataFrame) error {
+	return a.initColumn(df, a.name, INT, UINT, FLOAT, DECIMAL)
+}
+
+// Step adds the value of the row.
+func (a *meanAggregator) Step(row Row) error {
+	v, err := row.Cell(a.colName)
+	if err != nil || v.IsNull() {
+		return err
+	}
+
+	if a.exact {
+		if a.dec == nil {
+			a.dec = newDecimalSum()
+		}
+
+		d, _ := v.Decimal()
+		a.dec.add(d)
+	}
+
+	number, _ := v.toNumber()
+	a.count++
+	delta := number - a.mean
+	a.mean += delta / a.count
+	a.m2 += delta * (number - a.mean)
+	return nil
+}
+
+// Merge adds the values of other aggregator.
+func (a *meanAggregator) Merge(other Aggregator) error {
+	o, ok := other.(*meanAggregator)
+	if !ok || o.name != a.name {
+		return errNotMergeable
+	}
+
+	if o.count == 0 {
+		return nil
+	}
+
+	if o.dec != nil {
+		if a.dec == nil {
+			a.dec = newDecimalSum()
+		}
+
+		a.dec.merge(o.dec)
+	}
+
+	count := a.count + o.count
+	delta := o.mean - a.mean
+	a.mean += delta * o.count / count
+	a.m2 += o.m2 + delta*delta*a.count*o.count/count
+	a.count = count
+	return nil
+}
+
+// Result returns the mean, the variance or the standard deviation.
+func (a *meanAggregator) Result() Value {
+	switch {
+	case a.exact && a.dec != nil:
+		return newDecimalValue(a.dec.mean(a.rounding))
+	case a.name == "Mean" && a.count > 0:
+		return newFloatValue(a.mean)
+	case a.name == "Variance" && a.count > 1:
+		return newFloatValue(a.m2 / (a.count - 1))
+	case a.name == "Std" && a.count > 1:
+		return newFloatValue(math.Sqrt(a.m2 / (a.count - 1)))
+	default:
+		return Value{}
+	}
+}
+
+// New returns a new aggregator of the same column.
+func (a *meanAggregator) New() Aggregator {
+	return &meanAggregator{
+		aggregatorBase: aggregatorBase{colName: a.colName},
+		name:           a.name,
+		exact:          a.exact,
+		rounding:       a.rounding,
+	}
+}
+
+// operationAggregator struct adapts an Operation to the Aggregator interface.
+type operationAggregator struct {
+	op Operation
+}
+
+// OperationAggregator returns an Aggregator that executes the op operation in each step.
+// The result of the operation is stored in the operation, so the Result method of the
+// aggregator always returns a null value. The aggregator only can be merged, or executed
+// in parallel, if op implements the MergeableOperation interface.
+func OperationAggregator(op Operation) Aggregator {
+	return &operationAggregator{op}
+}
+
+// Init does nothing. The operations haven't initialization.
+func (a *operationAggregator) Init(df *DataFrame) error {
+	return nil
+}
+
+// Step executes the operation with the row.
+func (a *operationAggregator) Step(row Row) error {
+	if a.op == nil {
+		return errors.New("the operation isn't mergeable")
+	}
+
+	return a.op.F(&row)
+}
+
+// Merge merges the operation of other aggregator.
+func (a *operationAggregator) Merge(other Aggregator) error {
+	o, ok := other.(*operationAggregator)
+	if !ok {
+		return errNotMergeable
+	}
+
+	op, ok := a.op.(MergeableOperation)
+	if !ok {
+		return errors.New("the operation isn't mergeable")
+	}
+
+	oop, ok := o.op.(MergeableOperation)
+	if !ok {
+		return errors.New("the operation isn't mergeable")
+	}
+
+	return op.Merge(oop)
+}
+
+// Result returns a null value.
+func (a *operationAggregator) Result() Value {
+	return Value{}
+}
+
+// New returns a new aggregator with a new operation. If the operation isn't mergeable, the
+// aggregator returned generates an error in each step.
+func (a *operationAggregator) New() Aggregator {
+	if op, ok := a.op.(MergeableOperation); ok {
+		return &operationAggregator{op.New()}
+	}
+
+	return &operationAggregator{nil}
+}
+
+// aggregatorOperation struct adapts an Aggregator to the MergeableOperation interface,
+// so the aggregators can be executed with the operation methods.
+type aggregatorOperation struct {
+	agg Aggregator
+	df  *DataFrame
+	// error generated initializing the aggregator.
+	err error
+}
+
+// F executes a step of the aggregator.
+func (o *aggregatorOperation) F(r *Row) error {
+	if o.err != nil {
+		return o.err
+	}
+
+	return o.agg.Step(*r)
+}
+
+// New returns a new operation with a new aggregator, initialized with the same DataFrame.
+func (o *aggregatorOperation) New() MergeableOperation {
+	agg := o.agg.New()
+	return &aggregatorOperation{agg, o.df, agg.Init(o.df)}
+}
+
+// Merge merges the aggregator of the other operation.
+func (o *aggregatorOperation) Merge(other MergeableOperation) error {
+	op, ok := other.(*aggregatorOperation)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	return o.agg.Merge(op.agg)
+}
+
+// AggregateRange calculates the agg aggregator with the DataFrame rows between min and max,
+// and returns the result.
+func (df *DataFrame) AggregateRange(agg Aggregator, min, max int) (Value, error) {
+	if err := agg.Init(df); err != nil {
+		return Value{}, err
+	}
+
+	if err := df.OperationRange(&aggregatorOperation{agg, df, nil}, min, max); err != nil {
+		return Value{}, err
+	}
+
+	return agg.Result(), nil
+}
+
+// Aggregate calculates the agg aggregator with all DataFrame rows and returns the result.
+func (df *DataFrame) Aggregate(agg Aggregator) (Value, error) {
+	return df.AggregateRange(agg, 0, df.NumberRows())
+}
+
+// AggregateParallelRange calculates the agg aggregator with the DataFrame rows between min
+// and max, using several goroutines, and returns the result. See OperationParallelRange.
+func (df *DataFrame) AggregateParallelRange(
+	agg Aggregator, workers int, min, max int,
+) (Value, error) {
+	if err := agg.Init(df); err != nil {
+		return Value{}, err
+	}
+
+	op := aggregatorOperation{agg, df, nil}
+	if err := df.OperationParallelRange(&op, workers, min, max); err != nil {
+		return Value{}, err
+	}
+
+	return agg.Result(), nil
+}
+
+// AggregateParallel calculates the agg aggregator with all DataFrame rows, using several
+// goroutines, and returns the result. See OperationParallelRange.
+func (df *DataFrame) AggregateParallel(agg Aggregator, workers int) (Value, error) {
+	return df.AggregateParallelRange(agg, workers, 0, df.NumberRows())
+}
@@ -0,0 +1,206 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_SumAggregator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = getDataFrameToColumns(t); df == nil {
+		return
+	}
+
+	expected := map[string]interface{}{
+		"col A": int64(-45),
+		"col B": uint64(45),
+		"col D": 49.5 - 49.5i,
+	}
+
+	for colName, result := range expected {
+		value, err := df.Aggregate(NewSumAggregator(colName))
+		as.Nil(err, "there is an error in the aggregation")
+		as.Equalf(result, value.rawValue(), "the sum of %s is wrong", colName)
+	}
+
+	value, err := df.Aggregate(NewSumAggregator("col C"))
+	as.Nil(err, "there is an error in the aggregation")
+	f, _ := value.Float64()
+	as.Equal(float32(49.995), float32(f), "the sum is wrong")
+
+	// errors
+	_, err = df.Aggregate(NewSumAggregator("col E"))
+	as.Equal("Sum operation is invalid in column type string", err.Error(),
+		"invalid error message")
+
+	_, err = df.Aggregate(NewSumAggregator("not-exists"))
+	as.Equal("column not-exists not found", err.Error(), "invalid error message")
+
+	_, err = df.AggregateRange(NewSumAggregator("col A"), 3, 1)
+	as.Equal("max index < min index", err.Error(), "invalid error message")
+}
+
+func Test_CountAggregator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	one := 1
+	data := []struct {
+		A *int `colName:"a"`
+	}{
+		{&one}, {nil}, {&one},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	value, err := df.Aggregate(NewCountAggregator("a"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(int64(2), value.rawValue(), "the count is wrong")
+
+	value, err = df.Aggregate(NewCountAggregator(""))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(int64(3), value.rawValue(), "the count is wrong")
+}
+
+func Test_MinMaxAggregator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = getDataFrameToColumns(t); df == nil {
+		return
+	}
+
+	value, err := df.Aggregate(NewMinAggregator("col A"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(int64(-9), value.rawValue(), "the min is wrong")
+
+	value, err = df.Aggregate(NewMaxAggregator("col D"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(9.9-9.9i, value.rawValue(), "the max is wrong")
+
+	// strings
+	value, err = df.Aggregate(NewMaxAggregator("col E"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal("test 9", value.rawValue(), "the max is wrong")
+
+	// empty range
+	value, err = df.AggregateRange(NewMinAggregator("col A"), 2, 2)
+	as.Nil(err, "there is an error in the aggregation")
+	as.True(value.IsNull(), "the min of an empty range is null")
+
+	_, err = df.Aggregate(NewMinAggregator("not-exists"))
+	as.Equal("column not-exists not found", err.Error(), "invalid error message")
+}
+
+func Test_MeanAggregator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = getDataFrameToColumns(t); df == nil {
+		return
+	}
+
+	value, err := df.Aggregate(NewMeanAggregator("col A"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(float64(-5), value.rawValue(), "the mean is wrong")
+
+	value, err = df.Aggregate(NewVarianceAggregator("col B"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(7.5, value.rawValue(), "the variance is wrong")
+
+	value, err = df.Aggregate(NewStdAggregator("col B"))
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(math.Sqrt(7.5), value.rawValue(), "the standard deviation is wrong")
+
+	value, err = df.AggregateRange(NewStdAggregator("col B"), 0, 1)
+	as.Nil(err, "there is an error in the aggregation")
+	as.True(value.IsNull(), "the standard deviation of one value is null")
+
+	_, err = df.Aggregate(NewMeanAggregator("col D"))
+	as.Equal("Mean operation is invalid in column type complex", err.Error(),
+		"invalid error message")
+}
+
+func Test_DataFrame_AggregateParallel(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	data := []mockData{}
+	for i := 1; i <= 1000; i++ {
+		data = append(data, mockData{i, i % 10})
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	aggregators := []Aggregator{
+		NewSumAggregator("a"),
+		NewCountAggregator("a"),
+		NewMinAggregator("b"),
+		NewMaxAggregator("a"),
+		NewMeanAggregator("a"),
+		NewVarianceAggregator("b"),
+	}
+
+	for _, agg := range aggregators {
+		expected, err := df.Aggregate(agg.New())
+		as.Nil(err, "there is an error in the aggregation")
+
+		for _, workers := range []int{0, 2, 7} {
+			value, err := df.AggregateParallel(agg.New(), workers)
+			as.Nil(err, "there is an error in the aggregation")
+			e, _ := expected.toNumber()
+			v, _ := value.toNumber()
+			as.InDelta(e, v, 1e-9, "the parallel result is wrong")
+		}
+	}
+
+	_, err := df.AggregateParallel(NewSumAggregator("c"), 4)
+	as.Equal("column c not found", err.Error(), "invalid error message")
+
+	// merge errors
+	as.Equal(errNotMergeable, NewSumAggregator("a").Merge(NewMeanAggregator("a")),
+		"invalid error")
+	as.Equal(errNotMergeable, NewMeanAggregator("a").Merge(NewStdAggregator("a")),
+		"invalid error")
+}
+
+// mockOperation is an Operation that counts the rows. It isn't mergeable.
+type mockOperation struct {
+	count int
+}
+
+func (o *mockOperation) F(r *Row) error {
+	o.count++
+	return nil
+}
+
+func Test_OperationAggregator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df, _ = makeDataFrameMockData(t); df == nil {
+		return
+	}
+
+	// not mergeable operation.
+	op := mockOperation{}
+	value, err := df.Aggregate(OperationAggregator(&op))
+	as.Nil(err, "there is an error in the aggregation")
+	as.True(value.IsNull(), "the result of the operations is null")
+	as.Equal(df.NumberRows(), op.count, "the count is wrong")
+
+	_, err = df.AggregateParallel(OperationAggregator(&op), 2)
+	as.Equal("the operation isn't mergeable", err.Error(), "invalid error message")
+
+	// mergeable operation.
+	sum := OperatrionSumInt{OperationBaseInt{OperationBase{"a"}, 0}}
+	_, err = df.AggregateParallel(OperationAggregator(&sum), 4)
+	as.Nil(err, "there is an error in the aggregation")
+	as.Equal(int64(126), sum.Total, "the sum is wrong")
+}
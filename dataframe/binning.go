@@ -0,0 +1,269 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// formatEdge returns the edge of a bin as string.
+func formatEdge(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// binLabels returns the default labels of the bins made with the edges: "[e0, e1]" for the
+// first bin and "(e1, e2]" for the others.
+func binLabels(edges []float64) []string {
+	labels := make([]string, len(edges)-1)
+	for i := range labels {
+		open := "("
+		if i == 0 {
+			open = "["
+		}
+
+		labels[i] = open + formatEdge(edges[i]) + ", " + formatEdge(edges[i+1]) + "]"
+	}
+
+	return labels
+}
+
+// checkEdges checks if the edges of the bins are valid: at least 2 edges, in increasing order.
+func checkEdges(edges []float64) error {
+	if len(edges) < 2 {
+		return fmt.Errorf("there must be at least 2 edges")
+	}
+
+	for i, edge := range edges {
+		if math.IsNaN(edge) || (i > 0 && edge <= edges[i-1]) {
+			return fmt.Errorf("the edges must be increasing")
+		}
+	}
+
+	return nil
+}
+
+// binIndex returns the index of the bin of the f value. The bins are closed on the right,
+// except the first bin, that is closed on both sides. Returns -1 if the value isn't in any
+// bin.
+func binIndex(edges []float64, f float64) int {
+	if f < edges[0] || f > edges[len(edges)-1] {
+		return -1
+	} else if f == edges[0] {
+		return 0
+	}
+
+	// first edge greater than or equal to f.
+	return sort.SearchFloat64s(edges, f) - 1
+}
+
+// cut returns a new DataFrame with the newColName column, that contains the label of the bin
+// of the colName column value of each row. data is a snapshot of the DataFrame rows.
+func (df *DataFrame) cut(
+	data []map[string]Value, colName, newColName string, edges []float64, labels []string,
+) (*DataFrame, error) {
+	if err := checkEdges(edges); err != nil {
+		return nil, err
+	}
+
+	if labels == nil {
+		labels = binLabels(edges)
+	} else if len(labels) != len(edges)-1 {
+		return nil, fmt.Errorf("there are %d labels, but there are %d bins",
+			len(labels), len(edges)-1)
+	}
+
+	numeric, err := df.numericColumns(data, []string{colName})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]Value, len(data))
+	for i, f := range numeric[0].values {
+		if !numeric[0].valid[i] {
+			continue
+		}
+
+		if bin := binIndex(edges, f); bin != -1 {
+			values[i] = newStringValue(labels[bin])
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, STRING, values)
+}
+
+/*
+Cut returns a new DataFrame with the newColName column, type string, that contains the label
+of the bin of the colName column value of each row. The column must be type int, uint or
+float. The bins are defined by the edges, in increasing order: the bin i contains the values
+greater than edges[i] and less than or equal to edges[i+1], and the first bin contains the
+first edge too.
+
+The labels param contains the label of each bin. If it is nil, the labels are the ranges of
+the bins: "[0, 10]", "(10, 100]"... The null values, the NaN floats and the values out of the
+bins have null label.
+
+Example:
+	// latency buckets.
+	df, err := df.Cut("latency", "bucket", []float64{0, 100, 500, math.Inf(1)},
+		[]string{"fast", "normal", "slow"})
+*/
+func (df *DataFrame) Cut(
+	colName, newColName string, edges []float64, labels []string,
+) (*DataFrame, error) {
+	return df.cut(df.snapshot(), colName, newColName, edges, labels)
+}
+
+// quantile returns the q quantile of the sorted values, using the linear interpolation between
+// the closest values.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+/*
+QCut returns a new DataFrame with the newColName column, type string, that contains the bin of
+the colName column value of each row, like Cut. The edges of the bins are the quantiles of the
+column values: for example, {0, 0.25, 0.5, 0.75, 1} makes 4 bins with the same number of
+rows. The quantiles must be in the range [0, 1], in increasing order, and the labels are the
+ranges of the bins.
+
+Returns an error if the column hasn't values or some quantiles have the same value, because
+the bins would be empty.
+
+Example:
+	// order size quartiles.
+	df, err := df.QCut("size", "quartile", []float64{0, 0.25, 0.5, 0.75, 1})
+*/
+func (df *DataFrame) QCut(colName, newColName string, quantiles []float64) (*DataFrame, error) {
+	if err := checkEdges(quantiles); err != nil {
+		return nil, fmt.Errorf("invalid quantiles: %s", err.Error())
+	}
+
+	if quantiles[0] < 0 || quantiles[len(quantiles)-1] > 1 {
+		return nil, fmt.Errorf("the quantiles must be in the range [0, 1]")
+	}
+
+	data := df.snapshot()
+	numeric, err := df.numericColumns(data, []string{colName})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := []float64{}
+	for i, f := range numeric[0].values {
+		if numeric[0].valid[i] {
+			sorted = append(sorted, f)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("the column %s hasn't values", colName)
+	}
+
+	sort.Float64s(sorted)
+	edges := make([]float64, len(quantiles))
+	for i, q := range quantiles {
+		edges[i] = quantile(sorted, q)
+	}
+
+	if err := checkEdges(edges); err != nil {
+		return nil, fmt.Errorf("the quantile edges aren't unique")
+	}
+
+	return df.cut(data, colName, newColName, edges, nil)
+}
+
+// binEdge returns the lower edge of the i bin, whose half width is halfWidth. The half width
+// is added twice, so the edge doesn't overflow when the width is greater than the max float.
+func binEdge(min, halfWidth float64, i int) float64 {
+	return min + float64(i)*halfWidth + float64(i)*halfWidth
+}
+
+/*
+Histogram returns a new DataFrame with the histogram of the colName column, that must be type
+int, uint or float. The range between the min and the max values is split in bins of the same
+width, and the new DataFrame has a row for each bin with the columns "min" and "max", type
+float, with the edges of the bin, and "count", type int, with the number of values in the bin.
+
+The bins are closed on the left, except the last bin, that is closed on both sides. The null
+values and the NaN floats are ignored. If all values are equal, the range is the value ± 0.5.
+Returns an error if bins is less than 1 or the column hasn't values.
+*/
+func (df *DataFrame) Histogram(colName string, bins int) (*DataFrame, error) {
+	if bins < 1 {
+		return nil, fmt.Errorf("the number of bins must be positive")
+	}
+
+	numeric, err := df.numericColumns(df.snapshot(), []string{colName})
+	if err != nil {
+		return nil, err
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for i, f := range numeric[0].values {
+		if numeric[0].valid[i] {
+			min, max = math.Min(min, f), math.Max(max, f)
+		}
+	}
+
+	if min > max {
+		return nil, fmt.Errorf("the column %s hasn't values", colName)
+	}
+
+	if math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil, fmt.Errorf("the column %s has infinite values", colName)
+	}
+
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	// max-min overflows with the values near the float limits, so the values are halved to
+	// calculate the bin of each value. halfWidth is the half of the width of the bins.
+	halfWidth := (max/2 - min/2) / float64(bins)
+	counts := make([]int64, bins)
+
+	for i, f := range numeric[0].values {
+		if !numeric[0].valid[i] {
+			continue
+		}
+
+		pos := (f/2 - min/2) / halfWidth
+		switch {
+		case !(pos >= 0):
+			pos = 0
+		case pos >= float64(bins):
+			pos = float64(bins - 1) // the max value is in the last bin.
+		}
+
+		counts[int(pos)]++
+	}
+
+	columns := []column{
+		{name: "min", ctype: FLOAT, basicType: true},
+		{name: "max", ctype: FLOAT, basicType: true},
+		{name: "count", ctype: INT, basicType: true},
+	}
+
+	data := make([]map[string]Value, bins)
+	for i := range data {
+		upper := binEdge(min, halfWidth, i+1)
+		if i == bins-1 {
+			upper = max
+		}
+
+		data[i] = map[string]Value{
+			"min":   newFloatValue(binEdge(min, halfWidth, i)),
+			"max":   newFloatValue(upper),
+			"count": newIntValue(counts[i]),
+		}
+	}
+
+	return newDataFrameFromData(columns, data), nil
+}
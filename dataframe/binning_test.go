@@ -0,0 +1,163 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// makeBinningDataFrame returns a DataFrame with the latency column.
+func makeBinningDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"latency": []*float64{
+			floatPtr(0), floatPtr(50), floatPtr(100), floatPtr(101), nil, floatPtr(math.NaN()),
+			floatPtr(900), floatPtr(-1),
+		},
+	})
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+func Test_binIndex_func(t *testing.T) {
+	as := assert.New(t)
+	edges := []float64{0, 10, 20}
+
+	as.Equal(0, binIndex(edges, 0), "the bin is wrong")
+	as.Equal(0, binIndex(edges, 10), "the bin is wrong")
+	as.Equal(1, binIndex(edges, 10.5), "the bin is wrong")
+	as.Equal(1, binIndex(edges, 20), "the bin is wrong")
+	as.Equal(-1, binIndex(edges, 20.1), "the value is out of the bins")
+	as.Equal(-1, binIndex(edges, -0.1), "the value is out of the bins")
+}
+
+func Test_DataFrame_Cut_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeBinningDataFrame(t)
+
+	result, err := df.Cut("latency", "bucket", []float64{0, 100, 500}, []string{"fast", "slow"})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"latency", "bucket"}, result.Headers(), "the headers are wrong")
+
+	values, _ := result.ColumnRange("bucket", 0, 8)
+	expected := []string{"fast", "fast", "fast", "slow", "", "", "", ""}
+	for i, v := range values {
+		as.Equal(expected[i], v.String(), "the value of the row %d is wrong", i)
+	}
+
+	as.True(values[4].IsNull(), "the null values have null label")
+	as.True(values[6].IsNull(), "the values out of the bins have null label")
+
+	// default labels.
+	result, _ = df.Cut("latency", "bucket", []float64{-1, 0.5, 1000}, nil)
+	values, _ = result.ColumnRange("bucket", 0, 8)
+	as.Equal("[-1, 0.5]", values[0].String(), "the label is wrong")
+	as.Equal("(0.5, 1000]", values[1].String(), "the label is wrong")
+	as.Equal("[-1, 0.5]", values[7].String(), "the label is wrong")
+
+	// errors.
+	_, err = df.Cut("latency", "bucket", []float64{0}, nil)
+	as.Equal("there must be at least 2 edges", err.Error(), "the error message doesn't match")
+	_, err = df.Cut("latency", "bucket", []float64{0, 5, 5}, nil)
+	as.Equal("the edges must be increasing", err.Error(), "the error message doesn't match")
+	_, err = df.Cut("latency", "bucket", []float64{0, 5, 6}, []string{"a"})
+	as.Equal("there are 1 labels, but there are 2 bins", err.Error(),
+		"the error message doesn't match")
+	_, err = df.Cut("latency", "latency", []float64{0, 5}, nil)
+	as.Equal("the column latency is duplicated", err.Error(), "the error message doesn't match")
+	_, err = df.Cut("none", "bucket", []float64{0, 5}, nil)
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_QCut_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, _ := NewDataFrameFromColumns(map[string]interface{}{
+		"size": []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	})
+
+	result, err := df.QCut("size", "half", []float64{0, 0.5, 1})
+	as.Nil(err, "there is an error in the function")
+
+	values, _ := result.ColumnRange("half", 0, 9)
+	for i, v := range values {
+		expected := "[1, 5]"
+		if i >= 5 {
+			expected = "(5, 9]"
+		}
+
+		as.Equal(expected, v.String(), "the value of the row %d is wrong", i)
+	}
+
+	result, _ = df.QCut("size", "quartile", []float64{0, 0.25, 0.5, 0.75, 1})
+	values, _ = result.ColumnRange("quartile", 0, 9)
+	as.Equal("[1, 3]", values[2].String(), "the value is wrong")
+	as.Equal("(3, 5]", values[3].String(), "the value is wrong")
+	as.Equal("(7, 9]", values[8].String(), "the value is wrong")
+
+	// errors.
+	_, err = df.QCut("size", "q", []float64{0, 1.5})
+	as.Equal("the quantiles must be in the range [0, 1]", err.Error(),
+		"the error message doesn't match")
+	_, err = df.QCut("size", "q", []float64{0.5, 0.1})
+	as.Equal("invalid quantiles: the edges must be increasing", err.Error(),
+		"the error message doesn't match")
+
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"a": []int{1, 1, 1, 2}, "b": []*int{nil, nil, nil, nil},
+	})
+	_, err = df.QCut("a", "q", []float64{0, 0.5, 1})
+	as.Equal("the quantile edges aren't unique", err.Error(), "the error message doesn't match")
+	_, err = df.QCut("b", "q", []float64{0, 1})
+	as.Equal("the column b hasn't values", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Histogram_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeBinningDataFrame(t)
+
+	hist, err := df.Histogram("latency", 2)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"min", "max", "count"}, hist.Headers(), "the headers are wrong")
+
+	mins, _ := hist.ColumnAsFloat("min")
+	maxs, _ := hist.ColumnAsFloat("max")
+	counts, _ := hist.ColumnAsInt("count")
+	as.Equal([]float64{-1, 449.5}, mins, "the min edges are wrong")
+	as.Equal([]float64{449.5, 900}, maxs, "the max edges are wrong")
+	as.Equal([]int64{5, 1}, counts, "the counts are wrong")
+
+	// all values are equal.
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"a": []uint{3, 3}, "b": []*int{nil, nil}, "c": []float64{1, math.Inf(1)},
+	})
+	hist, _ = df.Histogram("a", 1)
+	mins, _ = hist.ColumnAsFloat("min")
+	counts, _ = hist.ColumnAsInt("count")
+	as.Equal([]float64{2.5}, mins, "the min edges are wrong")
+	as.Equal([]int64{2}, counts, "the counts are wrong")
+
+	// the range of the values overflows the float type.
+	limits, _ := NewDataFrameFromColumns(map[string]interface{}{
+		"f": []float64{-1e308, 1e308, 0, 5e307},
+	})
+	hist, err = limits.Histogram("f", 4)
+	as.Nil(err, "there is an error in the function")
+	mins, _ = hist.ColumnAsFloat("min")
+	maxs, _ = hist.ColumnAsFloat("max")
+	counts, _ = hist.ColumnAsInt("count")
+	as.Equal([]float64{-1e308, -5e307, 0, 5e307}, mins, "the min edges are wrong")
+	as.Equal([]float64{-5e307, 0, 5e307, 1e308}, maxs, "the max edges are wrong")
+	as.Equal([]int64{1, 0, 1, 2}, counts, "the counts are wrong")
+
+	_, err = df.Histogram("a", 0)
+	as.Equal("the number of bins must be positive", err.Error(),
+		"the error message doesn't match")
+	_, err = df.Histogram("b", 3)
+	as.Equal("the column b hasn't values", err.Error(), "the error message doesn't match")
+	_, err = df.Histogram("c", 3)
+	as.Equal("the column c has infinite values", err.Error(), "the error message doesn't match")
+}
@@ -0,0 +1,272 @@
+package dataframe
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// builderChunkSize is the number of rows of each chunk of the Builder storage.
+const builderChunkSize = 1024
+
+// Builder makes a DataFrame adding the rows incrementally, so the rows can be added as they
+// arrive, without an intermediate struct array. The rows are stored in chunks, so the storage
+// grows without copying the previous rows.
+//
+// The Builder is safe for concurrent use by multiple goroutines.
+//
+// Example:
+//	b, err := NewBuilder(myDataFrame{})
+//	for row := range rows {
+//		err = b.Append(row)
+//	}
+//
+//	df, err := b.Build()
+type Builder struct {
+	lock sync.Mutex
+	// DataFrame columns.
+	columns []column
+	// Struct type of the rows. It is nil when the Builder is made from a schema.
+	st reflect.Type
+	// Struct field of each column.
+	fields []structField
+	// Rows stored in chunks of builderChunkSize rows.
+	chunks [][]map[string]Value
+	// Number of rows.
+	numberRows int
+}
+
+// NewBuilder creates a new Builder whose columns are defined by the fields of the sample
+// struct, or ptr to struct, like in NewDataFrameFromStruct.
+func NewBuilder(sample interface{}) (*Builder, error) {
+	return NewBuilderWithOptions(sample, StructOptions{})
+}
+
+// NewBuilderWithOptions creates a new Builder whose columns are defined by the fields of the
+// sample struct, or ptr to struct, using the opts options to map the struct fields.
+func NewBuilderWithOptions(sample interface{}, opts StructOptions) (*Builder, error) {
+	t := reflect.TypeOf(sample)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the data type is not a struct")
+	}
+
+	fields, err := structColumns(t, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	b := Builder{st: t, fields: fields}
+	for _, field := range fields {
+		b.columns = append(b.columns, field.col)
+	}
+
+	return &b, nil
+}
+
+// NewBuilderFromSchema creates a new Builder whose columns are defined by the schema. The
+// rows are added using the AppendValues or AppendRecord functions.
+func NewBuilderFromSchema(schema []ColumnSchema) (*Builder, error) {
+	columns, err := newColumnsFromSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{columns: columns}, nil
+}
+
+// add adds the row to the Builder storage. The Builder must be locked.
+func (b *Builder) add(row map[string]Value) {
+	if b.numberRows%builderChunkSize == 0 {
+		b.chunks = append(b.chunks, make([]map[string]Value, 0, builderChunkSize))
+	}
+
+	last := len(b.chunks) - 1
+	b.chunks[last] = append(b.chunks[last], row)
+	b.numberRows++
+}
+
+// structRow returns the values of the row param, that must be a struct, or ptr to struct,
+// of the Builder struct type.
+func (b *Builder) structRow(row reflect.Value) (map[string]Value, error) {
+	if b.st == nil {
+		return nil, fmt.Errorf("the builder hasn't a struct type")
+	}
+
+	if row.Kind() == reflect.Ptr {
+		if row.IsNil() {
+			return nil, fmt.Errorf("the row is nil")
+		}
+
+		row = row.Elem()
+	}
+
+	if row.Type() != b.st {
+		return nil, fmt.Errorf("the row type is %s, but the builder type is %s",
+			row.Type(), b.st)
+	}
+
+	return structRowValues(row, b.columns, b.fields)
+}
+
+// Append adds a new row to the Builder. The row param must be a struct, or ptr to struct, of
+// the type used to create the Builder.
+func (b *Builder) Append(row interface{}) error {
+	values, err := b.structRow(reflect.ValueOf(row))
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.add(values)
+	return nil
+}
+
+// AppendValues adds a new row to the Builder, with the values param as the values of the
+// columns, in the same order. The values can be a Value or any valid type of the struct
+// fields, and the nil values are null values, like in NewDataFrameFromRecords.
+func (b *Builder) AppendValues(values ...interface{}) error {
+	if len(values) != len(b.columns) {
+		return fmt.Errorf("there are %d values, but there are %d columns",
+			len(values), len(b.columns))
+	}
+
+	row := make(map[string]Value, len(b.columns))
+	for i, col := range b.columns {
+		value, err := recordValue(values[i], col)
+		if err != nil {
+			return fmt.Errorf("in column %s: %s", col.name, err.Error())
+		}
+
+		row[col.name] = value
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.add(row)
+	return nil
+}
+
+// AppendRecord adds a new row to the Builder. The keys of the record are the column names,
+// like in NewDataFrameFromRecords.
+func (b *Builder) AppendRecord(record map[string]interface{}) error {
+	values := make([]interface{}, len(b.columns))
+	for i, col := range b.columns {
+		values[i] = record[col.name]
+	}
+
+	return b.AppendValues(values...)
+}
+
+// AppendChan adds the rows received by the ch channel, until it is closed. The channel
+// elements must be structs, or ptrs to struct, of the type used to create the Builder.
+// If a row is invalid then it returns an error without consuming the remaining rows.
+//
+// Example:
+//	ch := make(chan myDataFrame)
+//	go produce(ch)
+//	err := b.AppendChan(ch)
+func (b *Builder) AppendChan(ch interface{}) error {
+	cv := reflect.ValueOf(ch)
+	if cv.Kind() != reflect.Chan || cv.Type().ChanDir()&reflect.RecvDir == 0 {
+		return fmt.Errorf("invalid data type. Valid type: chan")
+	}
+
+	for row, ok := cv.Recv(); ok; row, ok = cv.Recv() {
+		values, err := b.structRow(row)
+		if err != nil {
+			return err
+		}
+
+		b.lock.Lock()
+		b.add(values)
+		b.lock.Unlock()
+	}
+
+	return nil
+}
+
+// AppendCsv adds the rows of the r csv source, using comma as column separator. The first
+// row of the csv must contain the column names, and the values are parsed with ParseValue
+// using the type of their column. The columns that aren't in the csv are null.
+func (b *Builder) AppendCsv(r io.Reader, comma rune) error {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("error reading the csv header: %s", err.Error())
+	}
+
+	columns := make([]column, len(header))
+	for i, name := range header {
+		pos := -1
+		for j, col := range b.columns {
+			if col.name == name {
+				pos = j
+			}
+		}
+
+		if pos == -1 {
+			return fmt.Errorf("column %s not found", name)
+		}
+
+		columns[i] = b.columns[pos]
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading the csv: %s", err.Error())
+		}
+
+		row := make(map[string]Value, len(b.columns))
+		for _, col := range b.columns {
+			row[col.name] = Value{}
+		}
+
+		for i, str := range record {
+			if row[columns[i].name], err = columns[i].parseString(str); err != nil {
+				return fmt.Errorf("in line %d, column %s: %s", line, columns[i].name, err.Error())
+			}
+		}
+
+		b.lock.Lock()
+		b.add(row)
+		b.lock.Unlock()
+	}
+}
+
+// Len returns the number of rows added to the Builder.
+func (b *Builder) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.numberRows
+}
+
+// Build creates a new DataFrame with the rows added to the Builder. Then the Builder is
+// emptied and it can be used to build a new DataFrame.
+func (b *Builder) Build() (*DataFrame, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	data := make([]map[string]Value, 0, b.numberRows)
+	for _, chunk := range b.chunks {
+		data = append(data, chunk...)
+	}
+
+	b.chunks = nil
+	b.numberRows = 0
+	return newDataFrameFromData(b.columns, data), nil
+}
@@ -0,0 +1,132 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_Builder_Append(t *testing.T) {
+	as := assert.New(t)
+
+	b, err := NewBuilder(&mockData{})
+	if err != nil {
+		as.FailNow("error creating the builder", "error: %s", err.Error())
+	}
+
+	as.Nil(b.Append(mockData{1, 2}), "there is an error in the function")
+	as.Nil(b.Append(&mockData{3, 4}), "there is an error in the function")
+	as.Nil(b.AppendValues(5, uint8(6)), "there is an error in the function")
+	as.Nil(b.AppendRecord(map[string]interface{}{"a": 7}), "there is an error in the function")
+	as.Equal(4, b.Len(), "the number of rows is wrong")
+
+	df, err := b.Build()
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"a", "b"}, df.Headers(), "the headers are wrong")
+	as.Equal([][]string{{"1", "2"}, {"3", "4"}, {"5", "6"}, {"7", ""}}, dataFrameRows(df),
+		"the rows are wrong")
+
+	// the builder is emptied.
+	as.Equal(0, b.Len(), "the builder isn't empty")
+
+	// errors.
+	err = b.Append(struct{ A int }{})
+	as.Equal("the row type is struct { A int }, but the builder type is dataframe.mockData",
+		err.Error(), "the error message doesn't match")
+	err = b.Append((*mockData)(nil))
+	as.Equal("the row is nil", err.Error(), "the error message doesn't match")
+	err = b.AppendValues(1)
+	as.Equal("there are 1 values, but there are 2 columns", err.Error(),
+		"the error message doesn't match")
+	err = b.AppendValues(1, "x")
+	as.Equal("in column b: the value type is string, but the column type is int", err.Error(),
+		"the error message doesn't match")
+
+	_, err = NewBuilder(3)
+	as.Equal("the data type is not a struct", err.Error(), "the error message doesn't match")
+}
+
+func Test_Builder_chunks(t *testing.T) {
+	as := assert.New(t)
+	b, _ := NewBuilder(mockData{})
+	n := builderChunkSize*2 + 10
+
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n/2; i++ {
+				b.Append(mockData{i, 1})
+			}
+		}()
+	}
+
+	wg.Wait()
+	as.Equal(3, len(b.chunks), "the number of chunks is wrong")
+	as.Equal(builderChunkSize, cap(b.chunks[0]), "the chunk size is wrong")
+
+	df, _ := b.Build()
+	as.Equal(n, df.NumberRows(), "the number of rows is wrong")
+	sum, _ := df.Sum("b")
+	as.Equal(int64(n), sum, "the rows are wrong")
+}
+
+func Test_Builder_AppendChan(t *testing.T) {
+	as := assert.New(t)
+	b, _ := NewBuilder(mockData{})
+
+	ch := make(chan mockData)
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch <- mockData{i, i * 2}
+		}
+		close(ch)
+	}()
+
+	as.Nil(b.AppendChan(ch), "there is an error in the function")
+	df, _ := b.Build()
+	values, _ := df.ColumnAsInt("b")
+	as.Equal([]int64{0, 2, 4, 6, 8}, values, "the rows are wrong")
+
+	err := b.AppendChan([]mockData{})
+	as.Equal("invalid data type. Valid type: chan", err.Error(),
+		"the error message doesn't match")
+
+	ints := make(chan int, 1)
+	ints <- 1
+	close(ints)
+	err = b.AppendChan(ints)
+	as.Equal("the row type is int, but the builder type is dataframe.mockData", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_Builder_AppendCsv(t *testing.T) {
+	as := assert.New(t)
+
+	b, err := NewBuilderFromSchema([]ColumnSchema{
+		{Name: "name", Type: STRING}, {Name: "price", Type: FLOAT}, {Name: "units", Type: INT},
+	})
+	if err != nil {
+		as.FailNow("error creating the builder", "error: %s", err.Error())
+	}
+
+	as.Nil(b.AppendCsv(strings.NewReader("price;name\n1.5;a\n;b\n"), ';'),
+		"there is an error in the function")
+
+	df, _ := b.Build()
+	as.Equal([][]string{{"a", "1.5", ""}, {"b", "", ""}}, dataFrameRows(df),
+		"the rows are wrong")
+
+	err = b.AppendCsv(strings.NewReader("price;color\n"), ';')
+	as.Equal("column color not found", err.Error(), "the error message doesn't match")
+	err = b.AppendCsv(strings.NewReader("price\n1\nx\n"), ';')
+	as.Equal("in line 3, column price: x is not a valid float value", err.Error(),
+		"the error message doesn't match")
+	err = b.AppendCsv(strings.NewReader(""), ';')
+	as.Equal("error reading the csv header: EOF", err.Error(), "the error message doesn't match")
+
+	err = b.Append(mockData{})
+	as.Equal("the builder hasn't a struct type", err.Error(), "the error message doesn't match")
+}
@@ -0,0 +1,522 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// castPolicy indicates what the Cast function does when a value can't be converted exactly.
+type castPolicy int8
+
+// The valid cast policies.
+const (
+	CAST_ERROR    castPolicy = 0 // returns an error.
+	CAST_SATURATE castPolicy = 1 // uses the nearest valid value.
+	CAST_NULL     castPolicy = 2 // the value is converted to null.
+)
+
+// CastOptions are the options used when the values of a column are converted to other type.
+type CastOptions struct {
+	// Overflow is the policy used when the value is out of the range of the new type.
+	// CAST_SATURATE uses the min or max value of the new type.
+	Overflow castPolicy
+	// PrecisionLoss is the policy used when the value loses precision: floats with decimals
+	// converted to integers, complex with imaginary part converted to real numbers or big
+	// integers converted to floats. CAST_SATURATE truncates or rounds the value.
+	PrecisionLoss castPolicy
+	// Invalid is the policy used with the strings that aren't numbers and the NaN floats
+	// converted to integers. Only CAST_ERROR and CAST_NULL are valid.
+	Invalid castPolicy
+	// NewColumn is the name of the column with the converted values. If it is empty then
+	// the converted column replaces the original column.
+	NewColumn string
+	// Scale is the number of decimals of the values converted to DECIMAL. The values with
+	// more decimals lose precision and they are rounded with the Rounding mode.
+	Scale int
+	// Rounding is the rounding mode of the values converted to DECIMAL.
+	Rounding roundingMode
+}
+
+// errCastOverflow, errCastPrecision and errCastInvalid are the errors returned when a value
+// can't be converted.
+var (
+	errCastOverflow  = errors.New("the value is out of range")
+	errCastPrecision = errors.New("the value loses precision")
+	errCastInvalid   = errors.New("the value is invalid")
+)
+
+// check checks if the options are valid.
+func (o *CastOptions) check() error {
+	for _, p := range []castPolicy{o.Overflow, o.PrecisionLoss} {
+		if p != CAST_ERROR && p != CAST_SATURATE && p != CAST_NULL {
+			return fmt.Errorf("invalid cast policy")
+		}
+	}
+
+	if o.Invalid != CAST_ERROR && o.Invalid != CAST_NULL {
+		return fmt.Errorf("invalid cast policy")
+	}
+
+	if err := checkScale(o.Scale); err != nil {
+		return err
+	}
+
+	return o.Rounding.check()
+}
+
+// apply applies the policy p when the conversion fails with the err error. saturated is the
+// value used with CAST_SATURATE.
+func (p castPolicy) apply(err error, saturated Value) (Value, error) {
+	switch p {
+	case CAST_SATURATE:
+		return saturated, nil
+	case CAST_NULL:
+		return Value{}, nil
+	default:
+		return Value{}, err
+	}
+}
+
+// floatToInt converts the f float to int, applying the options when the value can't be
+// converted exactly.
+func (o *CastOptions) floatToInt(f float64) (Value, error) {
+	switch {
+	case math.IsNaN(f):
+		return o.Invalid.apply(errCastInvalid, Value{})
+	case f >= math.MaxInt64:
+		return o.Overflow.apply(errCastOverflow, newIntValue(math.MaxInt64))
+	case f < math.MinInt64:
+		return o.Overflow.apply(errCastOverflow, newIntValue(math.MinInt64))
+	case f != math.Trunc(f):
+		return o.PrecisionLoss.apply(errCastPrecision, newIntValue(int64(f)))
+	default:
+		return newIntValue(int64(f)), nil
+	}
+}
+
+// floatToUint converts the f float to uint, applying the options when the value can't be
+// converted exactly.
+func (o *CastOptions) floatToUint(f float64) (Value, error) {
+	switch {
+	case math.IsNaN(f):
+		return o.Invalid.apply(errCastInvalid, Value{})
+	case f >= math.MaxUint64:
+		return o.Overflow.apply(errCastOverflow, newUintValue(math.MaxUint64))
+	case f <= -1:
+		return o.Overflow.apply(errCastOverflow, newUintValue(0))
+	case f != math.Trunc(f):
+		return o.PrecisionLoss.apply(errCastPrecision, newUintValue(uint64(math.Max(f, 0))))
+	default:
+		return newUintValue(uint64(f)), nil
+	}
+}
+
+// intToFloat converts the i integer to float, applying the options when the float can't
+// represent the integer exactly.
+func (o *CastOptions) intToFloat(i int64) (Value, error) {
+	f := float64(i)
+	if f < math.MaxInt64 && int64(f) == i {
+		return newFloatValue(f), nil
+	}
+
+	return o.PrecisionLoss.apply(errCastPrecision, newFloatValue(f))
+}
+
+// uintToFloat converts the u unsigned integer to float, applying the options when the float
+// can't represent the integer exactly.
+func (o *CastOptions) uintToFloat(u uint64) (Value, error) {
+	f := float64(u)
+	if f < math.MaxUint64 && uint64(f) == u {
+		return newFloatValue(f), nil
+	}
+
+	return o.PrecisionLoss.apply(errCastPrecision, newFloatValue(f))
+}
+
+// toFloat converts the number stored in v to float, applying the options.
+func (o *CastOptions) toFloat(v Value) (Value, error) {
+	switch v.Kind() {
+	case INT:
+		i, _ := v.Int64()
+		return o.intToFloat(i)
+	case UINT:
+		u, _ := v.Uint64()
+		return o.uintToFloat(u)
+	case COMPLEX:
+		c, _ := v.Complex128()
+		if imag(c) != 0 {
+			return o.PrecisionLoss.apply(errCastPrecision, newFloatValue(real(c)))
+		}
+
+		return newFloatValue(real(c)), nil
+	case DECIMAL:
+		d, _ := v.Decimal()
+		return newFloatValue(d.Float64()), nil
+	default:
+		return v, nil
+	}
+}
+
+// toDecimal converts the v value to decimal, with the scale of the options. The floats are
+// converted using their shortest decimal representation, so 0.1 is exactly 0.1.
+func (o *CastOptions) toDecimal(v Value) (Value, error) {
+	var r *big.Rat
+
+	switch v.Kind() {
+	case INT:
+		i, _ := v.Int64()
+		r = new(big.Rat).SetInt64(i)
+	case UINT:
+		u, _ := v.Uint64()
+		r = new(big.Rat).SetInt(new(big.Int).SetUint64(u))
+	case STRING, CATEGORY:
+		str, _ := v.Str()
+		d, err := ParseDecimal(strings.TrimSpace(str))
+		if err != nil {
+			return o.Invalid.apply(errCastInvalid, Value{})
+		}
+
+		r = d.rat()
+	default:
+		f, err := o.toFloat(v)
+		if err != nil || f.IsNull() {
+			return f, err
+		}
+
+		n, _ := f.Float64()
+		if math.IsNaN(n) {
+			return o.Invalid.apply(errCastInvalid, Value{})
+		} else if math.IsInf(n, 0) {
+			r = new(big.Rat).SetFloat64(math.Copysign(math.MaxFloat64, n))
+		} else {
+			r, _ = new(big.Rat).SetString(strconv.FormatFloat(n, 'f', -1, 64))
+		}
+	}
+
+	d, exact, err := newDecimalFromRat(r, o.Scale, o.Rounding)
+	if err != nil {
+		saturated := Decimal{math.MaxInt64, o.Scale}
+		if r.Sign() < 0 {
+			saturated.unscaled = math.MinInt64
+		}
+
+		return o.Overflow.apply(errCastOverflow, newDecimalValue(saturated))
+	}
+
+	if !exact {
+		return o.PrecisionLoss.apply(errCastPrecision, newDecimalValue(d))
+	}
+
+	return newDecimalValue(d), nil
+}
+
+// parseNumber parses the str string as a number of ctype type.
+// The integers that aren't valid integer strings are parsed as floats and then converted.
+func (o *CastOptions) parseNumber(str string, ctype columnType) (Value, error) {
+	str = strings.TrimSpace(str)
+
+	switch ctype {
+	case INT:
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return newIntValue(i), nil
+		}
+	case UINT:
+		if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return newUintValue(u), nil
+		}
+	case COMPLEX:
+		c, err := strconv.ParseComplex(str, 128)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return o.Invalid.apply(errCastInvalid, Value{})
+		}
+
+		if cmplx.IsInf(c) {
+			return o.Overflow.apply(errCastOverflow, newComplexValue(saturateComplex(c)))
+		}
+
+		return newComplexValue(c), nil
+	}
+
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return o.Invalid.apply(errCastInvalid, Value{})
+	}
+
+	if ctype == FLOAT {
+		if math.IsInf(f, 0) && !strings.Contains(strings.ToLower(str), "inf") {
+			return o.Overflow.apply(errCastOverflow, newFloatValue(math.Copysign(math.MaxFloat64, f)))
+		}
+
+		return newFloatValue(f), nil
+	}
+
+	return o.castValue(newFloatValue(f), ctype)
+}
+
+// saturateComplex replaces the infinite parts of the c complex by the max float.
+func saturateComplex(c complex128) complex128 {
+	r, i := real(c), imag(c)
+	if math.IsInf(r, 0) {
+		r = math.Copysign(math.MaxFloat64, r)
+	}
+
+	if math.IsInf(i, 0) {
+		i = math.Copysign(math.MaxFloat64, i)
+	}
+
+	return complex(r, i)
+}
+
+// castValue converts the v value to the ctype type, applying the options when the value
+// can't be converted exactly. The null values are always converted to null values and the
+// values of the same type are returned without changes.
+func (o *CastOptions) castValue(v Value, ctype columnType) (Value, error) {
+	kind := v.Kind()
+
+	if v.IsNull() || kind == ctype {
+		return v, nil
+	}
+
+	if ctype == STRING {
+		return newStringValue(v.String()), nil
+	}
+
+	if ctype == DECIMAL {
+		return o.toDecimal(v)
+	}
+
+	if kind == STRING || kind == CATEGORY {
+		str, _ := v.Str()
+		return o.parseNumber(str, ctype)
+	}
+
+	switch ctype {
+	case INT:
+		if kind == UINT {
+			u, _ := v.Uint64()
+			if u > math.MaxInt64 {
+				return o.Overflow.apply(errCastOverflow, newIntValue(math.MaxInt64))
+			}
+
+			return newIntValue(int64(u)), nil
+		}
+
+		f, err := o.toFloat(v)
+		if err != nil || f.IsNull() {
+			return f, err
+		}
+
+		n, _ := f.Float64()
+		return o.floatToInt(n)
+	case UINT:
+		if kind == INT {
+			i, _ := v.Int64()
+			if i < 0 {
+				return o.Overflow.apply(errCastOverflow, newUintValue(0))
+			}
+
+			return newUintValue(uint64(i)), nil
+		}
+
+		f, err := o.toFloat(v)
+		if err != nil || f.IsNull() {
+			return f, err
+		}
+
+		n, _ := f.Float64()
+		return o.floatToUint(n)
+	case FLOAT:
+		return o.toFloat(v)
+	case COMPLEX:
+		f, err := o.toFloat(v)
+		if err != nil || f.IsNull() {
+			return f, err
+		}
+
+		n, _ := f.Float64()
+		return newComplexValue(complex(n, 0)), nil
+	default:
+		return Value{}, fmt.Errorf("%s is an invalid type", ctype)
+	}
+}
+
+// Cast returns a new DataFrame where the values of the colName column are converted to the
+// ctype type. The valid types are: INT, UINT, FLOAT, COMPLEX, STRING, CATEGORY and DECIMAL. The
+// strings are parsed as numbers and the numbers are formatted using their String function. Only
+// the string columns can be converted to CATEGORY, using the default options of AsCategory.
+// The values converted to DECIMAL have the Scale of the options, and the decimal columns keep
+// their values: RoundDecimal changes their scale.
+// The opts param defines the name of the new column and what the function does with the
+// values that can't be converted exactly. By default, it returns an error.
+func (df *DataFrame) Cast(colName string, ctype columnType, opts CastOptions) (*DataFrame, error) {
+	pos, exists := df.cIndexByName[colName]
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	if ctype == CATEGORY {
+		return df.AsCategory(colName, CategoryOptions{NewColumn: opts.NewColumn})
+	}
+
+	if _, err := getColumnTypeFromString(string(ctype)); err != nil {
+		return nil, err
+	}
+
+	if err := opts.check(); err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	values := make([]Value, len(data))
+
+	for i, row := range data {
+		value := row[colName]
+		v, err := opts.castValue(value, ctype)
+		if err != nil {
+			return nil, fmt.Errorf("error casting the value %s of the column %s to %s: %s",
+				value.String(), colName, ctype, err)
+		}
+
+		values[i] = v
+	}
+
+	if opts.NewColumn != "" {
+		return df.newDataFrameWithColumn(data, opts.NewColumn, ctype, values)
+	}
+
+	for i, row := range data {
+		row[colName] = values[i]
+	}
+
+	columns := append([]column{}, df.columns...)
+	columns[pos].ctype, columns[pos].basicType = ctype, true
+	columns[pos].meta.ValueType = ""
+	return newDataFrameFromData(columns, data), nil
+}
+
+// columnCastRange returns the values between the rows min and max of the colName column
+// converted to the ctype type. Only the numeric columns can be converted and it returns
+// an error if some value loses precision or is out of range.
+func (df *DataFrame) columnCastRange(colname string, ctype columnType, min, max int) ([]Value, error) {
+	col, exists := df.getColumnByName(colname)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colname)
+	}
+
+	if col.ctype == STRING || col.ctype == CATEGORY {
+		return nil, fmt.Errorf("column %s can't be converted to %s", colname, ctype)
+	}
+
+	values, err := df.ColumnRange(colname, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := CastOptions{}
+	for i, v := range values {
+		if values[i], err = opts.castValue(v, ctype); err != nil {
+			return nil, fmt.Errorf("error casting the value %s of the column %s to %s: %s",
+				v.String(), colname, ctype, err)
+		}
+	}
+
+	return values, nil
+}
+
+// ColumnAsIntLenientRange returns the values between the rows min and max of the colName
+// column as an array of integers. Unlike ColumnAsIntRange, the column can be any numeric
+// type, whether its values can be converted to integers without losing precision.
+func (df *DataFrame) ColumnAsIntLenientRange(colname string, min, max int) ([]int64, error) {
+	values, err := df.columnCastRange(colname, INT, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int64, len(values))
+	for i := range values {
+		numbers[i], _ = values[i].Int64()
+	}
+
+	return numbers, nil
+}
+
+// ColumnAsIntLenient returns the colName column as an array of integers. The column can be
+// any numeric type.
+func (df *DataFrame) ColumnAsIntLenient(colname string) ([]int64, error) {
+	return df.ColumnAsIntLenientRange(colname, 0, df.NumberRows())
+}
+
+// ColumnAsUintLenientRange returns the values between the rows min and max of the colName
+// column as an array of unsigned integers. Unlike ColumnAsUintRange, the column can be any
+// numeric type, whether its values can be converted to unsigned integers without losing
+// precision.
+func (df *DataFrame) ColumnAsUintLenientRange(colname string, min, max int) ([]uint64, error) {
+	values, err := df.columnCastRange(colname, UINT, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]uint64, len(values))
+	for i := range values {
+		numbers[i], _ = values[i].Uint64()
+	}
+
+	return numbers, nil
+}
+
+// ColumnAsUintLenient returns the colName column as an array of unsigned integers. The
+// column can be any numeric type.
+func (df *DataFrame) ColumnAsUintLenient(colname string) ([]uint64, error) {
+	return df.ColumnAsUintLenientRange(colname, 0, df.NumberRows())
+}
+
+// ColumnAsFloatLenientRange returns the values between the rows min and max of the colName
+// column as an array of floats. Unlike ColumnAsFloatRange, the column can be any numeric
+// type, whether its values can be converted to floats without losing precision.
+func (df *DataFrame) ColumnAsFloatLenientRange(colname string, min, max int) ([]float64, error) {
+	values, err := df.columnCastRange(colname, FLOAT, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]float64, len(values))
+	for i := range values {
+		numbers[i], _ = values[i].Float64()
+	}
+
+	return numbers, nil
+}
+
+// ColumnAsFloatLenient returns the colName column as an array of floats. The column can be
+// any numeric type.
+func (df *DataFrame) ColumnAsFloatLenient(colname string) ([]float64, error) {
+	return df.ColumnAsFloatLenientRange(colname, 0, df.NumberRows())
+}
+
+// ColumnAsComplexLenientRange returns the values between the rows min and max of the colName
+// column as an array of complex numbers. Unlike ColumnAsComplexRange, the column can be any
+// numeric type, whether its values can be converted to complex without losing precision.
+func (df *DataFrame) ColumnAsComplexLenientRange(colname string, min, max int) ([]complex128, error) {
+	values, err := df.columnCastRange(colname, COMPLEX, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]complex128, len(values))
+	for i := range values {
+		numbers[i], _ = values[i].Complex128()
+	}
+
+	return numbers, nil
+}
+
+// ColumnAsComplexLenient returns the colName column as an array of complex numbers. The
+// column can be any numeric type.
+func (df *DataFrame) ColumnAsComplexLenient(colname string) ([]complex128, error) {
+	return df.ColumnAsComplexLenientRange(colname, 0, df.NumberRows())
+}
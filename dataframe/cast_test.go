@@ -0,0 +1,154 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_CastOptions_castValue_func(t *testing.T) {
+	as := assert.New(t)
+	opts := CastOptions{}
+
+	cases := []struct {
+		v      Value
+		ctype  columnType
+		result Value
+	}{
+		{newIntValue(-3), FLOAT, newFloatValue(-3)},
+		{newIntValue(3), UINT, newUintValue(3)},
+		{newIntValue(3), COMPLEX, newComplexValue(3)},
+		{newIntValue(3), STRING, newStringValue("3")},
+		{newUintValue(3), INT, newIntValue(3)},
+		{newFloatValue(4), INT, newIntValue(4)},
+		{newFloatValue(4.5), STRING, newStringValue("4.5")},
+		{newComplexValue(2), FLOAT, newFloatValue(2)},
+		{newStringValue(" 12 "), INT, newIntValue(12)},
+		{newStringValue("1e3"), UINT, newUintValue(1000)},
+		{newStringValue("1.25"), FLOAT, newFloatValue(1.25)},
+		{newStringValue("1+2i"), COMPLEX, newComplexValue(1 + 2i)},
+		{Value{}, INT, Value{}},
+	}
+
+	for _, c := range cases {
+		r, err := opts.castValue(c.v, c.ctype)
+		as.Nil(err, "there is an error casting %s to %s", c.v.String(), c.ctype)
+		as.Equal(c.result, r, "the value %s cast to %s is wrong", c.v.String(), c.ctype)
+	}
+
+	errs := []struct {
+		v     Value
+		ctype columnType
+		err   error
+	}{
+		{newIntValue(-1), UINT, errCastOverflow},
+		{newUintValue(math.MaxUint64), INT, errCastOverflow},
+		{newFloatValue(1e30), INT, errCastOverflow},
+		{newFloatValue(2.5), INT, errCastPrecision},
+		{newComplexValue(1 + 1i), FLOAT, errCastPrecision},
+		{newIntValue(math.MaxInt64), FLOAT, errCastPrecision},
+		{newFloatValue(math.NaN()), INT, errCastInvalid},
+		{newStringValue("abc"), FLOAT, errCastInvalid},
+		{newStringValue("1e400"), FLOAT, errCastOverflow},
+	}
+
+	for _, c := range errs {
+		_, err := opts.castValue(c.v, c.ctype)
+		as.Equal(c.err, err, "the error casting %s to %s is wrong", c.v.String(), c.ctype)
+	}
+
+	// saturate policy.
+	opts = CastOptions{Overflow: CAST_SATURATE, PrecisionLoss: CAST_SATURATE}
+	r, _ := opts.castValue(newIntValue(-1), UINT)
+	as.Equal(newUintValue(0), r, "the value isn't saturated")
+	r, _ = opts.castValue(newFloatValue(-1e30), INT)
+	as.Equal(newIntValue(math.MinInt64), r, "the value isn't saturated")
+	r, _ = opts.castValue(newFloatValue(-2.7), INT)
+	as.Equal(newIntValue(-2), r, "the value isn't truncated")
+	r, _ = opts.castValue(newComplexValue(1+1i), UINT)
+	as.Equal(newUintValue(1), r, "the imaginary part isn't dropped")
+
+	// null policy.
+	opts = CastOptions{Overflow: CAST_NULL, PrecisionLoss: CAST_NULL, Invalid: CAST_NULL}
+	r, err := opts.castValue(newFloatValue(2.5), INT)
+	as.Nil(err, "there is an error in the function")
+	as.True(r.IsNull(), "the value must be null")
+	r, _ = opts.castValue(newComplexValue(1+1i), INT)
+	as.True(r.IsNull(), "the value must be null")
+	r, _ = opts.castValue(newStringValue("x"), INT)
+	as.True(r.IsNull(), "the value must be null")
+}
+
+func Test_DataFrame_Cast(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df, _ = makeDataFrameMockData(t); df == nil {
+		return
+	}
+
+	result, err := df.Cast("a", FLOAT, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal(df.Headers(), result.Headers(), "the headers are wrong")
+	values, err := result.ColumnAsFloat("a")
+	as.Nil(err, "the column must be float")
+	ints, _ := df.ColumnAsInt("a")
+	for i := range ints {
+		as.Equal(float64(ints[i]), values[i], "the values are wrong")
+	}
+
+	// the original DataFrame isn't modified.
+	_, err = df.ColumnAsInt("a")
+	as.Nil(err, "the original column is modified")
+
+	result, err = df.Cast("b", STRING, CastOptions{NewColumn: "c"})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"a", "b", "c"}, result.Headers(), "the headers are wrong")
+	strs, _ := result.ColumnAsString("c")
+	as.Equal("1", strs[0], "the values are wrong")
+
+	_, err = df.Cast("x", STRING, CastOptions{})
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+	_, err = df.Cast("a", columnType("date"), CastOptions{})
+	as.Equal("date is an invalid type", err.Error(), "the error message doesn't match")
+	_, err = df.Cast("a", UINT, CastOptions{Invalid: CAST_SATURATE})
+	as.Equal("invalid cast policy", err.Error(), "the error message doesn't match")
+
+	strDf, _ := df.Cast("a", STRING, CastOptions{})
+	_, err = strDf.Cast("a", UINT, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+}
+
+func Test_DataFrame_ColumnAsLenient(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df, _ = makeDataFrameMockData(t); df == nil {
+		return
+	}
+
+	ints, _ := df.ColumnAsInt("a")
+
+	floats, err := df.ColumnAsFloatLenient("a")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(float64(ints[1]), floats[1], "the values are wrong")
+
+	uints, err := df.ColumnAsUintLenientRange("a", 0, 2)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]uint64{uint64(ints[0]), uint64(ints[1])}, uints, "the values are wrong")
+
+	complexes, err := df.ColumnAsComplexLenient("a")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(complex(float64(ints[2]), 0), complexes[2], "the values are wrong")
+
+	floatDf, _ := df.Cast("a", FLOAT, CastOptions{})
+	ints2, err := floatDf.ColumnAsIntLenient("a")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(ints, ints2, "the values are wrong")
+
+	strDf, _ := df.Cast("a", STRING, CastOptions{})
+	_, err = strDf.ColumnAsIntLenient("a")
+	as.Equal("column a can't be converted to int", err.Error(), "the error message doesn't match")
+	_, err = df.ColumnAsIntLenient("x")
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+}
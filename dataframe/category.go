@@ -0,0 +1,210 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// categoryDict is the dictionary of a category column: the categories and their codes. It
+// isn't modified after creating it, so it can be shared by several DataFrames.
+type categoryDict struct {
+	// categories contains the category of each code.
+	categories []string
+	// codes contains the code of each category.
+	codes map[string]int
+	// ordered flag indicates if the values are ordered by the code instead of the category.
+	ordered bool
+	// values contains the Value of each code. All cells with the same category share it.
+	values []Value
+}
+
+// newCategoryDict creates a new categoryDict with the categories. Returns an error if some
+// category is duplicated.
+func newCategoryDict(categories []string, ordered bool) (*categoryDict, error) {
+	dict := categoryDict{
+		categories: append([]string{}, categories...),
+		codes:      make(map[string]int, len(categories)),
+		ordered:    ordered,
+		values:     make([]Value, len(categories)),
+	}
+
+	for code, category := range dict.categories {
+		if _, exists := dict.codes[category]; exists {
+			return nil, fmt.Errorf("the category %s is duplicated", category)
+		}
+
+		dict.codes[category] = code
+		dict.values[code] = Value{&categoryType{&dict, code}}
+	}
+
+	return &dict, nil
+}
+
+// categoryType is the value of a category column. It implements the StringType interface,
+// with the category as value, so the category values can be read like strings.
+type categoryType struct {
+	dict *categoryDict
+	code int
+}
+
+// Value returns the category of the value.
+func (c *categoryType) Value() string {
+	return c.dict.categories[c.code]
+}
+
+// Compare compares the category of the value with the v param. In the ordered categories, the
+// category order is the order of the dictionary, and the strings that aren't categories are
+// compared alphabetically.
+func (c *categoryType) Compare(v string) Comparers {
+	if code, exists := c.dict.codes[v]; exists && c.dict.ordered {
+		return simpleIntType{int64(c.code)}.Compare(int64(code))
+	}
+
+	return simpleStringType{c.Value()}.Compare(v)
+}
+
+// String returns the category of the value.
+func (c *categoryType) String() string {
+	return c.Value()
+}
+
+// CategoryOptions struct defines the categories of the column converted by AsCategory.
+type CategoryOptions struct {
+	// Categories are the valid values of the column. If it is empty, the categories are the
+	// distinct values of the column, sorted alphabetically.
+	Categories []string
+	// Ordered flag orders the values in the order of the Categories, instead of the
+	// alphabetical order. The Categories must be defined.
+	Ordered bool
+	// NewColumn is the name of the new category column. If it is empty, the column is
+	// replaced.
+	NewColumn string
+}
+
+/*
+AsCategory returns a new DataFrame where the colName column, that must be type string, is
+converted to a category column. The category columns store a code of each value and a
+dictionary with the categories, shared by all values, so the columns with few distinct values
+use much less memory. The values are read like strings, and the Cast function with the STRING
+type converts the column to string again.
+
+The opts param defines the categories. By default, the categories are the distinct values of
+the column and the values are ordered alphabetically. With ordered categories, the Order
+function orders the values in the order of the categories. Returns an error if the column
+has a value that isn't one of the categories.
+
+Example:
+	// the sizes are ordered from S to XL.
+	df, err := df.AsCategory("size", CategoryOptions{
+		Categories: []string{"S", "M", "L", "XL"},
+		Ordered:    true,
+	})
+*/
+func (df *DataFrame) AsCategory(colName string, opts CategoryOptions) (*DataFrame, error) {
+	pos, exists := df.cIndexByName[colName]
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	if ctype := df.columns[pos].ctype; ctype != STRING && ctype != CATEGORY {
+		return nil, fmt.Errorf("the column %s isn't type string", colName)
+	}
+
+	if opts.Ordered && len(opts.Categories) == 0 {
+		return nil, fmt.Errorf("the categories of an ordered column must be defined")
+	}
+
+	data := df.snapshot()
+	categories := opts.Categories
+	if len(categories) == 0 {
+		distinct := map[string]bool{}
+		for _, row := range data {
+			if v := row[colName]; !v.IsNull() && !distinct[v.String()] {
+				distinct[v.String()] = true
+				categories = append(categories, v.String())
+			}
+		}
+
+		sort.Strings(categories)
+	}
+
+	dict, err := newCategoryDict(categories, opts.Ordered)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]Value, len(data))
+	for i, row := range data {
+		v := row[colName]
+		if v.IsNull() {
+			continue
+		}
+
+		code, exists := dict.codes[v.String()]
+		if !exists {
+			return nil, fmt.Errorf("the value %s of the column %s isn't a category",
+				v.String(), colName)
+		}
+
+		values[i] = dict.values[code]
+	}
+
+	if opts.NewColumn != "" {
+		return df.newDataFrameWithColumn(data, opts.NewColumn, CATEGORY, values)
+	}
+
+	for i, row := range data {
+		row[colName] = values[i]
+	}
+
+	columns := append([]column{}, df.columns...)
+	columns[pos].ctype, columns[pos].basicType = CATEGORY, true
+	columns[pos].meta.ValueType = ""
+	return newDataFrameFromData(columns, data), nil
+}
+
+// Categories returns the categories of the colName column, in the order of their codes.
+// The second value returned is true whether the categories are ordered. The dictionary is
+// stored in the values, so it returns an empty array if the column hasn't values. Returns an
+// error if the column isn't type category.
+func (df *DataFrame) Categories(colName string) ([]string, bool, error) {
+	if err := df.checkColumnIsValid(colName, CATEGORY); err != nil {
+		return nil, false, err
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	for i := 0; i < df.handler.Len(); i++ {
+		v, _ := df.handler.Get(i, colName)
+		if c, ok := v.value.(*categoryType); ok {
+			return append([]string{}, c.dict.categories...), c.dict.ordered, nil
+		}
+	}
+
+	return []string{}, false, nil
+}
+
+// CategoryCodes returns the code of each value of the colName column, that is the position
+// of its category in the Categories array. The null values have the code -1. The codes can be
+// used to group the rows without comparing the strings. Returns an error if the column isn't
+// type category.
+func (df *DataFrame) CategoryCodes(colName string) ([]int, error) {
+	if err := df.checkColumnIsValid(colName, CATEGORY); err != nil {
+		return nil, err
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	codes := make([]int, df.handler.Len())
+	for i := range codes {
+		v, _ := df.handler.Get(i, colName)
+		codes[i] = -1
+		if c, ok := v.value.(*categoryType); ok {
+			codes[i] = c.code
+		}
+	}
+
+	return codes, nil
+}
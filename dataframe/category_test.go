@@ -0,0 +1,148 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// makeCategoryDataFrame returns a DataFrame with the size and price columns.
+func makeCategoryDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"size":  []*string{strPtr("M"), strPtr("XL"), nil, strPtr("S"), strPtr("M"), strPtr("L")},
+		"price": []int{20, 40, 0, 10, 25, 30},
+	}, "size", "price")
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+func Test_DataFrame_AsCategory_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeCategoryDataFrame(t)
+
+	cat, err := df.AsCategory("size", CategoryOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal(CATEGORY, cat.Schema()[0].Type, "the column type is wrong")
+	as.Equal([]string{"M", "XL", "", "S", "M", "L"}, columnStrings(cat, "size"),
+		"the values are wrong")
+
+	categories, ordered, err := cat.Categories("size")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"L", "M", "S", "XL"}, categories, "the categories are sorted")
+	as.False(ordered, "the categories aren't ordered")
+
+	codes, err := cat.CategoryCodes("size")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]int{1, 3, -1, 2, 1, 0}, codes, "the codes are wrong")
+
+	// the values are read like strings.
+	values, _ := cat.ColumnRange("size", 0, 2)
+	as.Equal(CATEGORY, values[0].Kind(), "the value kind is wrong")
+	str, err := values[0].Str()
+	as.Nil(err, "the category values are strings")
+	as.Equal("M", str, "the value is wrong")
+
+	// the values with the same category share the value.
+	all, _ := cat.Column("size")
+	as.True(all[0].value == all[4].value, "the values of the same category are shared")
+	as.True(all[0].Equal(all[4]), "the values are equal")
+	as.False(all[0].Equal(newStringValue("M")), "a category isn't equal to a string")
+
+	// new column.
+	cat, err = df.AsCategory("size", CategoryOptions{NewColumn: "size_cat"})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"size", "price", "size_cat"}, cat.Headers(), "the headers are wrong")
+	as.Equal(STRING, cat.Schema()[0].Type, "the original column isn't modified")
+
+	// errors.
+	_, err = df.AsCategory("price", CategoryOptions{})
+	as.EqualError(err, "the column price isn't type string", "the error is wrong")
+
+	_, err = df.AsCategory("size", CategoryOptions{Ordered: true})
+	as.EqualError(err, "the categories of an ordered column must be defined",
+		"the error is wrong")
+
+	_, err = df.AsCategory("size", CategoryOptions{Categories: []string{"S", "M", "S"}})
+	as.EqualError(err, "the category S is duplicated", "the error is wrong")
+
+	_, err = df.AsCategory("size", CategoryOptions{Categories: []string{"S", "M", "L"}})
+	as.EqualError(err, "the value XL of the column size isn't a category", "the error is wrong")
+
+	_, err = df.AsCategory("none", CategoryOptions{})
+	as.EqualError(err, "column none not found", "the error is wrong")
+
+	_, _, err = df.Categories("size")
+	as.EqualError(err, "column size is not type category", "the error is wrong")
+}
+
+func Test_DataFrame_Category_Order(t *testing.T) {
+	as := assert.New(t)
+	df := makeCategoryDataFrame(t)
+
+	// unordered categories are ordered alphabetically.
+	cat, _ := df.AsCategory("size", CategoryOptions{})
+	as.Nil(cat.Order(OrderColumn{"size", ASC}), "there is an error ordering the rows")
+	as.Equal([]string{"L", "M", "M", "S", "XL", ""}, columnStrings(cat, "size"),
+		"the order is wrong")
+
+	// ordered categories.
+	cat, _ = df.AsCategory("size", CategoryOptions{
+		Categories: []string{"S", "M", "L", "XL"},
+		Ordered:    true,
+	})
+
+	as.Nil(cat.Order(OrderColumn{"size", ASC}), "there is an error ordering the rows")
+	as.Equal([]string{"S", "M", "M", "L", "XL", ""}, columnStrings(cat, "size"),
+		"the order is wrong")
+
+	max, err := cat.Max("size")
+	as.Nil(err, "there is an error in the function")
+	as.Equal("XL", max, "the max uses the category order")
+
+	err = cat.OrderWithOptions(OrderColumn{"size", ASC}.WithOptions(OrderOptions{Magnitude: true}))
+	as.EqualError(err, "the magnitude order is invalid in column size of type category",
+		"the error is wrong")
+}
+
+func Test_DataFrame_Category_Conversions(t *testing.T) {
+	as := assert.New(t)
+	df := makeCategoryDataFrame(t)
+
+	cat, err := df.Cast("size", CATEGORY, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal(CATEGORY, cat.Schema()[0].Type, "the column type is wrong")
+
+	str, err := cat.Cast("size", STRING, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal(STRING, str.Schema()[0].Type, "the column type is wrong")
+	values, _ := str.Column("size")
+	as.Equal(STRING, values[0].Kind(), "the value kind is wrong")
+	as.Equal([]string{"M", "XL", "", "S", "M", "L"}, columnStrings(str, "size"),
+		"the values are wrong")
+
+	// value counts.
+	counts, err := cat.ValueCounts("size")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"M", "XL", "S", "L"}, columnStrings(counts, "size"), "the values are wrong")
+	as.Equal([]string{"2", "1", "1", "1"}, columnStrings(counts, "count"), "the counts are wrong")
+
+	// export to string fields.
+	type sizeRow struct {
+		Size  string `colName:"size"`
+		Price int    `colName:"price"`
+	}
+
+	rows := []sizeRow{}
+	as.Nil(cat.ExportStruct(&rows), "there is an error exporting the rows")
+	as.Equal(sizeRow{"XL", 40}, rows[1], "the row is wrong")
+
+	// records.
+	values, _ = cat.Column("size")
+	records, err := NewDataFrameFromRecords([]map[string]interface{}{{"size": values[0]}},
+		[]ColumnSchema{{Name: "size", Type: STRING}})
+	as.Nil(err, "the category values can be stored in string columns")
+	as.Equal([]string{"M"}, columnStrings(records, "size"), "the values are wrong")
+}
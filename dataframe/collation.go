@@ -0,0 +1,160 @@
+package dataframe
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StringCollator interface is used to define the order of the string columns.
+// The Collator of the golang.org/x/text/collate package implements this interface, so it
+// can be used to order the columns following the rules of a concrete language.
+type StringCollator interface {
+	// CompareString returns a negative number whether a is less than b, 0 whether a is
+	// equal than b and a positive number whether a is great than b.
+	CompareString(a, b string) int
+}
+
+// CaseInsensitiveCollator orders the strings ignoring the case of the letters.
+type CaseInsensitiveCollator struct{}
+
+// CompareString compares a and b ignoring the case.
+func (c CaseInsensitiveCollator) CompareString(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// NaturalCollator orders the strings comparing the digits sequences as numbers,
+// so "file2" is less than "file10".
+type NaturalCollator struct {
+	// CaseInsensitive flag indicates the text between the numbers is compared ignoring
+	// the case.
+	CaseInsensitive bool
+}
+
+// isDigit returns true whether the byte b is an ASCII digit.
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// naturalChunk returns the first chunk of str. A chunk is a sequence of digits
+// or a sequence of not digits characters.
+func naturalChunk(str string) string {
+	digit := isDigit(str[0])
+	i := 1
+	for i < len(str) && isDigit(str[i]) == digit {
+		i++
+	}
+
+	return str[:i]
+}
+
+// compareNumbers compares the a and b digit sequences as numbers. Whether both numbers are
+// equal, the number with less leading zeros is the less.
+func compareNumbers(a, b string) int {
+	ta := strings.TrimLeft(a, "0")
+	tb := strings.TrimLeft(b, "0")
+
+	if len(ta) != len(tb) {
+		return len(ta) - len(tb)
+	}
+
+	if c := strings.Compare(ta, tb); c != 0 {
+		return c
+	}
+
+	return len(a) - len(b)
+}
+
+// CompareString compares a and b using the natural order.
+func (c NaturalCollator) CompareString(a, b string) int {
+	for a != "" && b != "" {
+		ca, cb := naturalChunk(a), naturalChunk(b)
+
+		var comp int
+		if isDigit(ca[0]) && isDigit(cb[0]) {
+			comp = compareNumbers(ca, cb)
+		} else if c.CaseInsensitive {
+			comp = strings.Compare(strings.ToLower(ca), strings.ToLower(cb))
+		} else {
+			comp = strings.Compare(ca, cb)
+		}
+
+		if comp != 0 {
+			return comp
+		}
+
+		a, b = a[len(ca):], b[len(cb):]
+	}
+
+	return len(a) - len(b)
+}
+
+// latinBaseLetters contains the base letters of the latin letters with diacritics.
+var latinBaseLetters = map[rune]string{
+	'ß': "ss", 'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i", 'î': "i",
+	'ï': "i", 'ð': "d", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'ø': "o", 'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'þ': "th", 'ÿ': "y",
+	'ā': "a", 'ă': "a", 'ą': "a", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c", 'ď': "d",
+	'đ': "d", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e", 'ĝ': "g", 'ğ': "g",
+	'ġ': "g", 'ģ': "g", 'ĥ': "h", 'ħ': "h", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i",
+	'ı': "i", 'ĳ': "ij", 'ĵ': "j", 'ķ': "k", 'ĸ': "k", 'ĺ': "l", 'ļ': "l", 'ľ': "l",
+	'ŀ': "l", 'ł': "l", 'ń': "n", 'ņ': "n", 'ň': "n", 'ŉ': "n", 'ŋ': "n", 'ō': "o",
+	'ŏ': "o", 'ő': "o", 'œ': "oe", 'ŕ': "r", 'ŗ': "r", 'ř': "r", 'ś': "s", 'ŝ': "s",
+	'ş': "s", 'š': "s", 'ţ': "t", 'ť': "t", 'ŧ': "t", 'ũ': "u", 'ū': "u", 'ŭ': "u",
+	'ů': "u", 'ű': "u", 'ų': "u", 'ŵ': "w", 'ŷ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+	'ſ': "s",
+}
+
+// UnicodeCollator orders the strings following the multilevel comparison of the Unicode
+// collation algorithm: first it compares the base letters, ignoring the diacritics and the
+// case; then the diacritics and, at last, the case (lower case first). So "Émile" is less than
+// "Eva", and "cote" < "côte" < "Côte".
+//
+// It only knows the latin letters and it doesn't apply the tailorings of each language.
+// To order the strings using the rules of a concrete language use the Collator of the
+// golang.org/x/text/collate package.
+type UnicodeCollator struct{}
+
+// unicodePrimaryKey returns str without diacritics and in lower case.
+func unicodePrimaryKey(str string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(str) {
+		if base, ok := latinBaseLetters[r]; ok {
+			b.WriteString(base)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// CompareString compares a and b using the Unicode collation levels.
+func (c UnicodeCollator) CompareString(a, b string) int {
+	// primary level: base letters.
+	if comp := strings.Compare(unicodePrimaryKey(a), unicodePrimaryKey(b)); comp != 0 {
+		return comp
+	}
+
+	// secondary level: diacritics.
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if comp := strings.Compare(la, lb); comp != 0 {
+		return comp
+	}
+
+	// tertiary level: case. The lower case letters are the less.
+	ra, rb := []rune(a), []rune(b)
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		if ra[i] == rb[i] {
+			continue
+		}
+
+		if unicode.IsLower(ra[i]) {
+			return -1
+		}
+
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
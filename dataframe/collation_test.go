@@ -0,0 +1,49 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// checkCollator checks the collator c orders the strings of the sorted array.
+func checkCollator(as *assert.Assertions, c StringCollator, sorted []string) {
+	for i := 0; i < len(sorted); i++ {
+		for j := 0; j < len(sorted); j++ {
+			comp := toComparers(c.CompareString(sorted[i], sorted[j]))
+			as.Equalf(
+				toComparers(i-j), comp,
+				"comparing %s and %s the result is wrong", sorted[i], sorted[j])
+		}
+	}
+}
+
+func Test_CaseInsensitiveCollator_func(t *testing.T) {
+	as := assert.New(t)
+	c := CaseInsensitiveCollator{}
+
+	checkCollator(as, c, []string{"alpha", "Beta", "gamma"})
+	as.Equal(0, c.CompareString("Test", "tEST"), "the strings must be equal")
+}
+
+func Test_NaturalCollator_func(t *testing.T) {
+	as := assert.New(t)
+
+	checkCollator(as, NaturalCollator{}, []string{
+		"File1", "file", "file1", "file2", "file02", "file10", "file10a", "file10b", "file100",
+	})
+	checkCollator(as, NaturalCollator{true}, []string{
+		"a", "B1", "b2", "B10", "c",
+	})
+	as.Equal(0, NaturalCollator{true}.CompareString("File10", "file10"),
+		"the strings must be equal")
+}
+
+func Test_UnicodeCollator_func(t *testing.T) {
+	as := assert.New(t)
+
+	checkCollator(as, UnicodeCollator{}, []string{
+		"Ana", "Ángel", "Émile", "Eva", "Zoe",
+	})
+	checkCollator(as, UnicodeCollator{}, []string{"cote", "Cote", "côte", "Côte"})
+	checkCollator(as, UnicodeCollator{}, []string{"strasse", "Strasse", "straße", "Straße"})
+}
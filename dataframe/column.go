@@ -1,6 +1,8 @@
 package dataframe
 
 import (
+	"database/sql"
+	"encoding"
 	"fmt"
 	"reflect"
 )
@@ -10,11 +12,13 @@ type columnType string
 
 // Constans with the valid basic types for the columns.
 const (
-	INT     columnType = "int"
-	UINT    columnType = "uint"
-	FLOAT   columnType = "float"
-	COMPLEX columnType = "complex"
-	STRING  columnType = "string"
+	INT      columnType = "int"
+	UINT     columnType = "uint"
+	FLOAT    columnType = "float"
+	COMPLEX  columnType = "complex"
+	STRING   columnType = "string"
+	CATEGORY columnType = "category" // strings stored as codes of a dictionary. See AsCategory.
+	DECIMAL  columnType = "decimal"  // fixed-point decimals. See Decimal.
 )
 
 // getColumnTypeFromString returns one of the columnType constant depending of the param.
@@ -23,7 +27,7 @@ func getColumnTypeFromString(str string) (columnType, error) {
 	coltype := columnType(str)
 
 	switch coltype {
-	case INT, UINT, FLOAT, COMPLEX, STRING:
+	case INT, UINT, FLOAT, COMPLEX, STRING, DECIMAL:
 		return coltype, nil
 	default:
 		return columnType(""), fmt.Errorf("%s is an invalid type", str)
@@ -36,6 +40,8 @@ func getColumnTypeFromString(str string) (columnType, error) {
 //	- struct or interface that implements a ValueType (IntType, FloatType...)
 //	- Ptr to basic type: (int, uint, float, complex, string)
 //	- Ptr to struct or interface that implements a ValueType (IntType, FloatType...)
+//	- database/sql nullable types (sql.NullInt64, sql.NullString...) or ptr to them.
+//	- type that implements encoding.TextMarshaler or ptr to it.
 //
 // The function returns the columnType. One bool value, indicating if the the type of t param
 // is a basic type. And an error if t contains and invalid type.
@@ -64,7 +70,20 @@ func getColumnTypeFromType(t reflect.Type) (columnType, bool, error) {
 		if t.Implements(reflect.TypeOf((*StringType)(nil)).Elem()) {
 			return STRING, false, nil
 		}
+		if t.Implements(reflect.TypeOf((*DecimalType)(nil)).Elem()) {
+			return DECIMAL, false, nil
+		}
+	}
+
+	if ctype, exists := sqlNullTypes[t]; exists {
+		return ctype, false, nil
+	}
+
+	if isTextMarshaler(t) {
+		return STRING, false, nil
+	}
 
+	if k == reflect.Struct || k == reflect.Interface {
 		return columnType(""), false, fmt.Errorf("type doesn't implements a ValueType")
 	}
 
@@ -85,6 +104,24 @@ func getColumnTypeFromType(t reflect.Type) (columnType, bool, error) {
 	}
 }
 
+// sqlNullTypes contains the valid nullable types of the database/sql package and the column
+// type used to store them.
+var sqlNullTypes = map[reflect.Type]columnType{
+	reflect.TypeOf(sql.NullInt64{}):   INT,
+	reflect.TypeOf(sql.NullInt32{}):   INT,
+	reflect.TypeOf(sql.NullInt16{}):   INT,
+	reflect.TypeOf(sql.NullByte{}):    UINT,
+	reflect.TypeOf(sql.NullFloat64{}): FLOAT,
+	reflect.TypeOf(sql.NullString{}):  STRING,
+	reflect.TypeOf(sql.NullTime{}):    STRING,
+}
+
+// isTextMarshaler checks if the t type, or its ptr, implements encoding.TextMarshaler.
+func isTextMarshaler(t reflect.Type) bool {
+	marshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	return t.Implements(marshaler) || reflect.PtrTo(t).Implements(marshaler)
+}
+
 // Kind returns the Kind type associate to the columnType constants.
 // If columnType isn't one of the constants then the function throw a panic message.
 func (c columnType) Kind() reflect.Kind {
@@ -97,8 +134,10 @@ func (c columnType) Kind() reflect.Kind {
 		return reflect.Float64
 	case COMPLEX:
 		return reflect.Complex128
-	case STRING:
+	case STRING, CATEGORY:
 		return reflect.String
+	case DECIMAL:
+		return reflect.Struct
 	default:
 		panic("invalid column type")
 	}
@@ -114,6 +153,81 @@ type column struct {
 	index int
 	// flag indicating if is a basic type.
 	basicType bool
+	// column metadata.
+	meta ColumnMeta
+}
+
+// ColumnMeta contains the metadata of a DataFrame column. It is defined with the options of
+// the colName tag, like `colName:"price,format=%.2f,desc=Unit price,unit=EUR"`.
+type ColumnMeta struct {
+	// Format is the fmt format used to export the column values, like %.2f.
+	Format string
+	// Description of the column.
+	Description string
+	// Unit of the column values.
+	Unit string
+	// Scale is the number of decimals of the DECIMAL columns. The values parsed from strings,
+	// like the CSV values, are extended to this scale, and it returns an error if they have
+	// more decimals. If it is 0, the parsed values keep their decimals.
+	Scale int
+	// ValueType is the name of the custom type of the column values, registered with
+	// RegisterValueType. The values parsed from strings, like the CSV values, are made with
+	// the custom type. If it is empty, they are made with the basic type of the column.
+	ValueType string
+}
+
+// ColumnSchema contains the info of a DataFrame column.
+type ColumnSchema struct {
+	Name string     // Column name.
+	Type columnType // Column type.
+	Meta ColumnMeta // Column metadata.
+}
+
+// formatValue returns the v value as string, using the format of the column, if it is
+// defined. The null values are returned as an empty string.
+func (c *column) formatValue(v Value) string {
+	if c.meta.Format == "" || v.IsNull() {
+		return v.String()
+	}
+
+	return fmt.Sprintf(c.meta.Format, v.rawValue())
+}
+
+// parseString parses the str string as a value of the column, like ParseValue, or like
+// ParseCustomValue if the column has a custom value type. The decimals are extended to the
+// scale of the column, if it is defined. Returns an error if they have more decimals than the
+// scale.
+func (c *column) parseString(str string) (Value, error) {
+	if c.meta.ValueType != "" {
+		if str == "" {
+			return Value{}, nil
+		}
+
+		return ParseCustomValue(c.meta.ValueType, str)
+	}
+
+	v, err := ParseValue(c.ctype, str)
+	if err != nil || c.ctype != DECIMAL || c.meta.Scale == 0 || v.IsNull() {
+		return v, err
+	}
+
+	d, _ := v.Decimal()
+	if d.scale > c.meta.Scale {
+		return Value{}, fmt.Errorf("%s has more than %d decimals", str, c.meta.Scale)
+	}
+
+	d, err = d.Round(c.meta.Scale, ROUND_DOWN)
+	return newDecimalValue(d), err
+}
+
+// Schema returns the info of the DataFrame columns: the name, the type and the metadata.
+func (df *DataFrame) Schema() []ColumnSchema {
+	schema := []ColumnSchema{}
+	for _, col := range df.columns {
+		schema = append(schema, ColumnSchema{col.name, col.ctype, col.meta})
+	}
+
+	return schema
 }
 
 // orderType is the type used when it defines the order of the DataFrame rows.
@@ -127,8 +241,9 @@ const (
 
 // internalOrderColumn is the internal struct used to stored the order of the DataFrame rows.
 type internalOrderColumn struct {
-	column *column
-	order  orderType
+	column  *column
+	order   orderType
+	options OrderOptions
 }
 
 /*
@@ -186,7 +301,10 @@ func (df *DataFrame) ColumnRange(colname string, min, max int) ([]Value, error)
 		return nil, fmt.Errorf("column %s not found", colname)
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		// invalid range index.
 		return nil, err
@@ -212,7 +330,10 @@ func (df *DataFrame) ColumnAsIntRange(colname string, min, max int) ([]int64, er
 		return values, err
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return values, err
 	}
@@ -241,7 +362,10 @@ func (df *DataFrame) ColumnAsUintRange(colname string, min, max int) ([]uint64,
 		return values, err
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return values, err
 	}
@@ -272,7 +396,10 @@ func (df *DataFrame) ColumnAsFloatRange(colname string, min, max int) ([]float64
 		return values, err
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return values, err
 	}
@@ -303,7 +430,10 @@ func (df *DataFrame) ColumnAsComplexRange(colname string, min, max int) ([]compl
 		return values, err
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return values, err
 	}
@@ -334,7 +464,10 @@ func (df *DataFrame) ColumnAsStringRange(colname string, min, max int) ([]string
 		return values, err
 	}
 
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return values, err
 	}
@@ -1,9 +1,11 @@
 package dataframe
 
 import (
+	"database/sql"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_getColumnTypeFromString_func(t *testing.T) {
@@ -84,6 +86,29 @@ func Test_getColumnTypeFromKind_func(t *testing.T) {
 		"the error message doesn't match")
 }
 
+func Test_getColumnTypeFromType_func_Special(t *testing.T) {
+	as := assert.New(t)
+	types := map[columnType][]interface{}{
+		INT:    {sql.NullInt64{}, sql.NullInt32{}, sql.NullInt16{}, new(sql.NullInt64)},
+		UINT:   {sql.NullByte{}},
+		FLOAT:  {sql.NullFloat64{}},
+		STRING: {sql.NullString{}, sql.NullTime{}, time.Time{}, new(time.Time), textEnum(0)},
+	}
+
+	for c, arr := range types {
+		for _, elem := range arr {
+			ct, baseType, err := getColumnTypeFromType(reflect.TypeOf(elem))
+			as.Nil(err, "there is an error in the function")
+			as.Equalf(c, ct, "columType doesn't match in %T", elem)
+			as.False(baseType, "the type isn't a base type")
+		}
+	}
+
+	_, _, err := getColumnTypeFromType(reflect.TypeOf(sql.NullBool{}))
+	as.Equal("type doesn't implements a ValueType", err.Error(),
+		"the error message doesn't match")
+}
+
 func Test_Kind_func(t *testing.T) {
 	as := assert.New(t)
 	types := map[string]reflect.Kind{
@@ -575,3 +600,25 @@ func Test_DataFrame_ColumnAsString_func(t *testing.T) {
 	as.Nil(values, "values is not nil when the column is invalid")
 	as.Equal(err.Error(), "column col A is not type string")
 }
+
+func Test_DataFrame_Schema_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromStruct([]struct {
+		Name  string  `colName:"name,desc=Product name"`
+		Price float64 `colName:"price,format=%.2f,desc=Unit price,unit=EUR"`
+	}{})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]ColumnSchema{
+		{"name", STRING, ColumnMeta{"", "Product name", "", 0, ""}},
+		{"price", FLOAT, ColumnMeta{"%.2f", "Unit price", "EUR", 0, ""}},
+	}, df.Schema(), "the schema is wrong")
+
+	// the metadata is kept in the derived DataFrames.
+	result, _ := df.Cast("price", STRING, CastOptions{})
+	as.Equal("EUR", result.Schema()[1].Meta.Unit, "the metadata is lost")
+}
@@ -27,6 +27,20 @@ Example:
 	// Create the new DataFrame
 	df, err := NewDataFrameFromStruct(data)
 
+Column options
+
+The colName tag can contain several options after the column name, separated by commas:
+	- type: overrides the column type. Example: `colName:"price,type=float"`
+	- format: fmt format used to export the values. Example: `colName:"price,format=%.2f"`
+	- desc: column description. Example: `colName:"price,desc=Unit price"`
+	- unit: unit of the column values. Example: `colName:"price,unit=EUR"`
+	- scale: decimals of the decimal columns. Example: `colName:"price,type=decimal,scale=2"`
+	- prefix: prefix of the nested struct columns. Example: `colName:"address,prefix=addr_"`
+
+The metadata of the columns is returned by the Schema function. The fields with the
+`colName:"-"` tag are omitted always. Using NewDataFrameFromStructWithOptions with the AutoNames
+option, the fields without colName tag are added too, using the field name in snake case.
+
 Valid Types
 
 In the struct fields only are valid the next basic types:
@@ -46,12 +60,16 @@ In the struct fields only are valid the next basic types:
 	- complex128
 	- string
 
+The ptrs to the valid types are valid too. The nil ptrs are stored in the DataFrame as
+null values.
+
 Also it can use a struct, if it implements the Values interface:
 	- IntType
 	- UintType
 	- FloatType
 	- ComplexType
 	- StringType
+	- DecimalType
 
 Example:
 	// Custom struct
@@ -86,11 +104,27 @@ Example:
 	}
 
 	df, _ := NewDataFrameFromStruct(data)
+
+The nullable types of the database/sql package are valid too, and the invalid values are
+stored as null values:
+	- sql.NullInt64, sql.NullInt32 and sql.NullInt16 (int column)
+	- sql.NullByte (uint column)
+	- sql.NullFloat64 (float column)
+	- sql.NullString (string column)
+	- sql.NullTime (string column)
+
+The Decimal type, a fixed-point decimal number, is stored in decimal columns. It is used to
+store money without the rounding errors of the floats.
+
+The types that implement the encoding.TextMarshaler interface (UUIDs, enums, time.Time...)
+are stored in string columns, using the text returned by the MarshalText function.
 */
 package dataframe
 
 import (
 	"fmt"
+	"io"
+	"sync"
 )
 
 // DataHandler interface it is used to manipulate the data of DataFrame.
@@ -107,7 +141,15 @@ type DataHandler interface {
 
 // DataFrame struct is the main struct in the package.
 // It provides a set of methods to get and manipulate all data in dataframe.
+//
+// The DataFrame is safe for concurrent use by multiple goroutines: the reads of the cells
+// and the Order method are synchronized. The methods that read several rows (operations,
+// columns, exports...) block the Order until they finish, so they always see the rows in the
+// same order. But an Order executed while other goroutine iterates the rows with an Iterator
+// changes the rows read after it.
 type DataFrame struct {
+	// lock synchronizes the access to the data between the readers and the Order method.
+	lock sync.RWMutex
 	// cIndexByName cIndexByName field is a map to save the column name and his position.
 	cIndexByName map[string]int
 	// columns field is an array with the info of all columns.
@@ -146,6 +188,61 @@ func (df *DataFrame) checkRange(min, max int) error {
 	return nil
 }
 
+// newDataFrameFromData creates a new DataFrame with the columns and the data rows. The data
+// rows are maps with the values of each column.
+func newDataFrameFromData(columns []column, data []map[string]Value) *DataFrame {
+	df := DataFrame{}
+	df.columns = []column{}
+	df.cIndexByName = map[string]int{}
+	df.order = []internalOrderColumn{}
+
+	for i, c := range columns {
+		c.index = i
+		df.columns = append(df.columns, c)
+		df.cIndexByName[c.name] = i
+	}
+
+	df.handler = &dataHandlerStruct{dataframe: &df, data: data}
+	return &df
+}
+
+// snapshot returns a copy of the data of all DataFrame rows, in the current order.
+func (df *DataFrame) snapshot() []map[string]Value {
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	data := make([]map[string]Value, df.handler.Len())
+	for i := range data {
+		row := make(map[string]Value, len(df.columns))
+		for _, col := range df.columns {
+			row[col.name], _ = df.handler.Get(i, col.name)
+		}
+
+		data[i] = row
+	}
+
+	return data
+}
+
+// newDataFrameWithColumn creates a new DataFrame with the df columns and a new column, named
+// name and type ctype. data param is a snapshot of the df rows, and values contains the
+// values of the new column in each row. Returns an error if the column already exists.
+func (df *DataFrame) newDataFrameWithColumn(
+	data []map[string]Value, name string, ctype columnType, values []Value,
+) (*DataFrame, error) {
+	if _, exists := df.cIndexByName[name]; exists {
+		return nil, fmt.Errorf("the column %s is duplicated", name)
+	}
+
+	for i, row := range data {
+		row[name] = values[i]
+	}
+
+	columns := append([]column{}, df.columns...)
+	columns = append(columns, column{name: name, ctype: ctype, basicType: true})
+	return newDataFrameFromData(columns, data), nil
+}
+
 // Headers returns the columns header of dataframe.
 func (df *DataFrame) Headers() []string {
 	header := []string{}
@@ -172,10 +269,38 @@ func (df *DataFrame) IteratorRange(min, max int) (*Iterator, error) {
 	return newIterator(df, min, max)
 }
 
+// lockedIteratorRange creates a new Iterator with the range specified in the parameters, to
+// use while the caller holds the read lock of the DataFrame. The rows of the iterator read
+// the cells without locking the DataFrame.
+func (df *DataFrame) lockedIteratorRange(min, max int) (*Iterator, error) {
+	iterator, err := newIterator(df, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator.locked = true
+	return iterator, nil
+}
+
 // Order orders the DataFrame rows using the newOrder array.
 // Returns an error if the column name is not exists.
 func (df *DataFrame) Order(newOrder ...OrderColumn) error {
-	df.order = []internalOrderColumn{}
+	orderOptions := []OrderColumnOptions{}
+	for _, extOrder := range newOrder {
+		orderOptions = append(orderOptions, extOrder.WithOptions(OrderOptions{}))
+	}
+
+	return df.OrderWithOptions(orderOptions...)
+}
+
+// OrderWithOptions orders the DataFrame rows using the newOrder array. Each column can define
+// how its values are compared: custom comparer, collation, magnitude and nulls position.
+// Returns an error if the column name is not exists or the options are invalid.
+func (df *DataFrame) OrderWithOptions(newOrder ...OrderColumnOptions) error {
+	df.lock.Lock()
+	defer df.lock.Unlock()
+
+	order := make([]internalOrderColumn, 0, len(newOrder))
 	for _, extOrder := range newOrder {
 		// check if the colums exists.
 		col, exists := df.getColumnByName(extOrder.Name)
@@ -183,8 +308,27 @@ func (df *DataFrame) Order(newOrder ...OrderColumn) error {
 			return fmt.Errorf("The column %s doesn't exists", extOrder.Name)
 		}
 
-		df.order = append(df.order, internalOrderColumn{col, extOrder.Order})
+		if err := extOrder.OrderOptions.check(col); err != nil {
+			return err
+		}
+
+		order = append(order, internalOrderColumn{col, extOrder.Order, extOrder.OrderOptions})
 	}
 
+	// the order is changed only when all columns are valid.
+	df.order = order
 	return df.handler.Order()
 }
+
+// Close releases the resources used by the DataFrame, like the temporal files of the
+// ordered csv DataFrames. The DataFrame can be used after closing it.
+func (df *DataFrame) Close() error {
+	df.lock.Lock()
+	defer df.lock.Unlock()
+
+	if closer, ok := df.handler.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// newColumnsFromSchema creates the columns of a new DataFrame using the schema info.
+// Returns an error if the schema has duplicated columns or invalid types.
+func newColumnsFromSchema(schema []ColumnSchema) ([]column, error) {
+	columns := []column{}
+	names := map[string]bool{}
+
+	for _, s := range schema {
+		if names[s.Name] {
+			return nil, fmt.Errorf("the column %s is duplicated", s.Name)
+		}
+
+		if _, err := getColumnTypeFromString(string(s.Type)); err != nil {
+			return nil, fmt.Errorf("in column %s: %s", s.Name, err.Error())
+		}
+
+		if s.Meta.ValueType != "" {
+			if err := checkValueType(s.Meta.ValueType, s.Type); err != nil {
+				return nil, fmt.Errorf("in column %s: %s", s.Name, err.Error())
+			}
+		}
+
+		names[s.Name] = true
+		col := column{name: s.Name, ctype: s.Type, basicType: true, meta: s.Meta}
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+// NewDataFrameFromColumns creates a new DataFrame using the columns param as data. The keys
+// of the map are the column names and the values are the column values, in a slice or array.
+// All columns must have the same length. The element type of the slices can be any valid type
+// of the struct fields (int, *float64, sql.NullString, custom types...).
+//
+// The names param defines the order of the columns and must contain all columns. If it is
+// empty, the columns are sorted by name.
+//
+// Example:
+//	df, err := NewDataFrameFromColumns(map[string]interface{}{
+//		"country": []string{"ES", "FR"},
+//		"people":  []int{47, 67},
+//	}, "country", "people")
+func NewDataFrameFromColumns(columns map[string]interface{}, names ...string) (*DataFrame, error) {
+	if len(names) == 0 {
+		for name := range columns {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+	}
+
+	if len(names) != len(columns) {
+		for name := range columns {
+			if !containsString(names, name) {
+				return nil, fmt.Errorf("the names don't contain the column %s", name)
+			}
+		}
+	}
+
+	cols := []column{}
+	values := []reflect.Value{}
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("the column %s is duplicated", name)
+		}
+
+		data, exists := columns[name]
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", name)
+		}
+
+		dv := reflect.ValueOf(data)
+		if data == nil || !dataHasValidType(data) {
+			return nil, fmt.Errorf(
+				"in column %s: invalid data type. Valid type: array, array ptr, slice, slice ptr",
+				name)
+		}
+
+		if dv.Kind() == reflect.Ptr {
+			dv = dv.Elem()
+		}
+
+		ctype, basicType, err := getColumnTypeFromType(dv.Type().Elem())
+		if err != nil {
+			return nil, fmt.Errorf("in column %s: %s", name, err.Error())
+		}
+
+		if len(values) > 0 && dv.Len() != values[0].Len() {
+			return nil, fmt.Errorf("the column %s has %d rows, but the column %s has %d rows",
+				name, dv.Len(), cols[0].name, values[0].Len())
+		}
+
+		seen[name] = true
+		cols = append(cols, column{name: name, ctype: ctype, basicType: basicType})
+		values = append(values, dv)
+	}
+
+	numberRows := 0
+	if len(values) > 0 {
+		numberRows = values[0].Len()
+	}
+
+	rows := make([]map[string]Value, numberRows)
+	for i := range rows {
+		rows[i] = make(map[string]Value, len(cols))
+	}
+
+	for j, col := range cols {
+		for i := range rows {
+			value, err := parseValue(values[j].Index(i), col)
+			if err != nil {
+				return nil, fmt.Errorf("in column %s: %s", col.name, err.Error())
+			}
+
+			rows[i][col.name] = *value
+		}
+	}
+
+	return newDataFrameFromData(cols, rows), nil
+}
+
+// containsString checks if the str string is in the strs array.
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordValue transforms the v value of a record in a Value of the col column.
+// The nil values are null values, and the numbers are converted to the column type, whether
+// they don't lose precision.
+func recordValue(v interface{}, col column) (Value, error) {
+	value, ok := v.(Value)
+
+	if v == nil {
+		return Value{}, nil
+	} else if !ok {
+		ctype, basicType, err := getColumnTypeFromType(reflect.TypeOf(v))
+		if err != nil {
+			return Value{}, err
+		}
+
+		vcol := column{name: col.name, ctype: ctype, basicType: basicType}
+		parsed, err := parseValue(reflect.ValueOf(v), vcol)
+		if err != nil {
+			return Value{}, err
+		}
+
+		value = *parsed
+	} else if value.Kind() == CATEGORY {
+		value = newStringValue(value.String())
+	}
+
+	if kind := value.Kind(); kind != col.ctype && (kind == STRING || col.ctype == STRING) {
+		return Value{}, fmt.Errorf("the value type is %s, but the column type is %s",
+			kind, col.ctype)
+	}
+
+	opts := CastOptions{Scale: col.meta.Scale}
+	return opts.castValue(value, col.ctype)
+}
+
+// NewDataFrameFromRecords creates a new DataFrame using the records param as data. Each
+// record is a row, where the keys are the column names. The schema defines the columns of
+// the DataFrame: names, types and metadata. The missing keys and the nil values are null
+// values, and the keys that aren't in the schema are ignored.
+//
+// The record values can be a Value or any valid type of the struct fields. The numbers are
+// converted to the column type, whether they don't lose precision.
+//
+// Example:
+//	df, err := NewDataFrameFromRecords([]map[string]interface{}{
+//		{"country": "ES", "people": 47},
+//		{"country": "FR", "people": 67},
+//	}, []ColumnSchema{{Name: "country", Type: STRING}, {Name: "people", Type: INT}})
+func NewDataFrameFromRecords(
+	records []map[string]interface{}, schema []ColumnSchema,
+) (*DataFrame, error) {
+	columns, err := newColumnsFromSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]Value, len(records))
+	for i, record := range records {
+		rows[i] = make(map[string]Value, len(columns))
+
+		for _, col := range columns {
+			if rows[i][col.name], err = recordValue(record[col.name], col); err != nil {
+				return nil, fmt.Errorf("in record %d, column %s: %s", i, col.name, err.Error())
+			}
+		}
+	}
+
+	return newDataFrameFromData(columns, rows), nil
+}
+
+// NewDataFrameFromMatrix creates a new DataFrame, with float columns, using the matrix param
+// as data. Each matrix row is a DataFrame row and the names param contains the column names.
+// All matrix rows must have a value for each column.
+func NewDataFrameFromMatrix(matrix [][]float64, names []string) (*DataFrame, error) {
+	schema := []ColumnSchema{}
+	for _, name := range names {
+		schema = append(schema, ColumnSchema{Name: name, Type: FLOAT})
+	}
+
+	columns, err := newColumnsFromSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]Value, len(matrix))
+	for i, values := range matrix {
+		if len(values) != len(names) {
+			return nil, fmt.Errorf("the row %d has %d values, but there are %d columns",
+				i, len(values), len(names))
+		}
+
+		rows[i] = make(map[string]Value, len(names))
+		for j, name := range names {
+			rows[i][name] = newFloatValue(values[j])
+		}
+	}
+
+	return newDataFrameFromData(columns, rows), nil
+}
@@ -0,0 +1,113 @@
+package dataframe
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_NewDataFrameFromColumns_func(t *testing.T) {
+	as := assert.New(t)
+	one := 1.5
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"country": []string{"ES", "FR", "IT"},
+		"people":  &[]int32{47, 67, 59},
+		"area":    [3]*float64{&one, nil, &one},
+		"code":    []sql.NullInt64{{Int64: 34, Valid: true}, {}, {Int64: 39, Valid: true}},
+	}, "country", "people", "area", "code")
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"country", "people", "area", "code"}, df.Headers(),
+		"the headers are wrong")
+	as.Equal([][]string{
+		{"ES", "47", "1.5", "34"},
+		{"FR", "67", "", ""},
+		{"IT", "59", "1.5", "39"},
+	}, dataFrameRows(df), "the rows are wrong")
+
+	// the columns are sorted by name.
+	df, err = NewDataFrameFromColumns(map[string]interface{}{
+		"b": []int{1}, "a": []string{"x"},
+	})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"a", "b"}, df.Headers(), "the headers are wrong")
+
+	// errors.
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": []int{1}, "b": []int{1}}, "a")
+	as.Equal("the names don't contain the column b", err.Error(),
+		"the error message doesn't match")
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": []int{1}}, "a", "a")
+	as.Equal("the column a is duplicated", err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": []int{1}}, "b")
+	as.Equal("column b not found", err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": 1})
+	as.Equal("in column a: invalid data type. Valid type: array, array ptr, slice, slice ptr",
+		err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": []bool{true}})
+	as.Equal("in column a: bool type is invalid", err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromColumns(map[string]interface{}{"a": []int{1}, "b": []int{1, 2}})
+	as.Equal("the column b has 2 rows, but the column a has 1 rows", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_NewDataFrameFromRecords_func(t *testing.T) {
+	as := assert.New(t)
+	schema := []ColumnSchema{
+		{Name: "country", Type: STRING},
+		{Name: "people", Type: FLOAT, Meta: ColumnMeta{Unit: "millions"}},
+		{Name: "code", Type: UINT},
+	}
+
+	df, err := NewDataFrameFromRecords([]map[string]interface{}{
+		{"country": "ES", "people": 47, "code": 34, "other": true},
+		{"country": "FR", "people": 67.5, "code": nil},
+		{"country": newStringValue("IT"), "people": uint8(59)},
+	}, schema)
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal(schema, df.Schema(), "the schema is wrong")
+	as.Equal([][]string{{"ES", "47", "34"}, {"FR", "67.5", ""}, {"IT", "59", ""}},
+		dataFrameRows(df), "the rows are wrong")
+
+	// errors.
+	_, err = NewDataFrameFromRecords([]map[string]interface{}{{"code": -1}}, schema)
+	as.Equal("in record 0, column code: the value is out of range", err.Error(),
+		"the error message doesn't match")
+	_, err = NewDataFrameFromRecords([]map[string]interface{}{{"country": 3}}, schema)
+	as.Equal("in record 0, column country: the value type is int, but the column type is string",
+		err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromRecords([]map[string]interface{}{{"code": true}}, schema)
+	as.Equal("in record 0, column code: bool type is invalid", err.Error(),
+		"the error message doesn't match")
+	_, err = NewDataFrameFromRecords(nil, []ColumnSchema{{Name: "a", Type: INT}, {Name: "a"}})
+	as.Equal("the column a is duplicated", err.Error(), "the error message doesn't match")
+	_, err = NewDataFrameFromRecords(nil, []ColumnSchema{{Name: "a", Type: "date"}})
+	as.Equal("in column a: date is an invalid type", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_NewDataFrameFromMatrix_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromMatrix([][]float64{{1, 2}, {3, 4.5}}, []string{"x", "y"})
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"x", "y"}, df.Headers(), "the headers are wrong")
+	values, _ := df.ColumnAsFloat("y")
+	as.Equal([]float64{2, 4.5}, values, "the values are wrong")
+
+	_, err = NewDataFrameFromMatrix([][]float64{{1, 2}, {3}}, []string{"x", "y"})
+	as.Equal("the row 1 has 1 values, but there are 2 columns", err.Error(),
+		"the error message doesn't match")
+	_, err = NewDataFrameFromMatrix([][]float64{}, []string{"x", "x"})
+	as.Equal("the column x is duplicated", err.Error(), "the error message doesn't match")
+}
@@ -0,0 +1,362 @@
+package dataframe
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Default values of the CsvOptions.
+const (
+	defaultCsvPageSize   = 1024
+	defaultCsvCachePages = 16
+	defaultCsvSortMemory = 64 << 20
+)
+
+// CsvOptions struct is used to define the options to read a csv file as DataFrame.
+type CsvOptions struct {
+	// contains the csv column separator. By default ','.
+	Comma rune
+	// Columns of the DataFrame. The names must be in the csv header and the other csv columns
+	// are ignored. If it is empty, all csv columns are loaded as string columns.
+	Schema []ColumnSchema
+	// Number of rows of each page read from the file. By default 1024.
+	PageSize int
+	// Max number of pages stored in the cache. By default 16.
+	CachePages int
+	// Approximate memory, in bytes, used to order the rows. The rows that don't fit in the
+	// memory are spilled to temporal files. By default 64 MiB.
+	SortMemory int64
+	// Directory of the temporal files. By default the os.TempDir directory.
+	TempDir string
+}
+
+// csvPageCache is a LRU cache with the pages of rows read from the csv file.
+type csvPageCache struct {
+	lock     sync.Mutex
+	capacity int
+	pages    map[int]*list.Element
+	lru      *list.List
+}
+
+// csvPage is a page of rows stored in the cache.
+type csvPage struct {
+	number int
+	rows   []map[string]Value
+}
+
+// get returns the rows of the page number. Returns false if the page isn't in the cache.
+func (c *csvPageCache) get(number int) ([]map[string]Value, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, exists := c.pages[number]
+	if !exists {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*csvPage).rows, true
+}
+
+// put stores the rows of the page number in the cache, removing the least recently used page
+// when the cache is full.
+func (c *csvPageCache) put(number int, rows []map[string]Value) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, exists := c.pages[number]; exists {
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.pages[number] = c.lru.PushFront(&csvPage{number, rows})
+
+	if c.lru.Len() > c.capacity {
+		last := c.lru.Back()
+		c.lru.Remove(last)
+		delete(c.pages, last.Value.(*csvPage).number)
+	}
+}
+
+// clear removes all pages of the cache.
+func (c *csvPageCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pages = map[int]*list.Element{}
+	c.lru.Init()
+}
+
+// dataHandlerCsv struct handles the data stored in a csv file. The file isn't loaded in
+// memory: the offsets of the rows are indexed when the DataFrame is created and the rows are
+// read on demand, by pages, that are stored in a LRU cache. When the rows are ordered, they
+// are read from a temporal file with the ordered rows.
+type dataHandlerCsv struct {
+	// Ptr to the dataframe object.
+	dataframe *DataFrame
+	// csv filename.
+	filename string
+	// csv column separator.
+	comma rune
+	// Position of each DataFrame column in the csv rows.
+	positions map[string]int
+	// Offset of each row in the file. The last offset is the end of the last row.
+	offsets []int64
+	// Number of rows of each page.
+	pageSize int
+	// Cache of pages.
+	cache *csvPageCache
+	// File with the ordered rows. It is nil if the rows aren't ordered.
+	sorted *rowsFile
+	// Approximate memory used to order the rows.
+	sortMemory int64
+	// Directory of the temporal files.
+	tempDir string
+}
+
+// NewDataFrameFromCsvFile creates a new DataFrame using as data the csv file with the filename
+// name. The first row of the file must contain the column names.
+//
+// The file isn't loaded in memory, so it can be larger than the memory. When the DataFrame is
+// created, the file is read to index the offsets of the rows. Then the rows are read from the
+// file when they are used, by pages, and the last used pages are stored in a cache. The file
+// mustn't be modified while the DataFrame is used.
+//
+// The csv DataFrames are ordered with an external merge sort: the rows are ordered in runs
+// that fit in the SortMemory option, the runs are spilled to temporal files and they are
+// merged in a new temporal file, from which the ordered rows are read. The Close function
+// removes the temporal file. In the ordered rows, the values of the custom types are stored
+// as its basic types.
+func NewDataFrameFromCsvFile(filename string, opts CsvOptions) (*DataFrame, error) {
+	dh := dataHandlerCsv{
+		filename:   filename,
+		comma:      opts.Comma,
+		positions:  map[string]int{},
+		sortMemory: opts.SortMemory,
+		tempDir:    opts.TempDir,
+	}
+
+	if dh.comma == 0 {
+		dh.comma = ','
+	}
+
+	if dh.pageSize = opts.PageSize; dh.pageSize <= 0 {
+		dh.pageSize = defaultCsvPageSize
+	}
+
+	if dh.sortMemory <= 0 {
+		dh.sortMemory = defaultCsvSortMemory
+	}
+
+	cachePages := opts.CachePages
+	if cachePages <= 0 {
+		cachePages = defaultCsvCachePages
+	}
+
+	dh.cache = &csvPageCache{
+		capacity: cachePages, pages: map[int]*list.Element{}, lru: list.New(),
+	}
+
+	header, err := dh.index()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the %s file: %s", filename, err.Error())
+	}
+
+	schema := opts.Schema
+	if len(schema) == 0 {
+		for _, name := range header {
+			schema = append(schema, ColumnSchema{Name: name, Type: STRING})
+		}
+	}
+
+	columns, err := newColumnsFromSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the %s file: %s", filename, err.Error())
+	}
+
+	for _, col := range columns {
+		dh.positions[col.name] = -1
+		for i, name := range header {
+			if name == col.name {
+				dh.positions[col.name] = i
+			}
+		}
+
+		if dh.positions[col.name] == -1 {
+			return nil, fmt.Errorf("error reading the %s file: column %s not found in the csv header",
+				filename, col.name)
+		}
+	}
+
+	df := newDataFrameFromData(columns, nil)
+	dh.dataframe = df
+	df.handler = &dh
+	return df, nil
+}
+
+// index reads the file to make the offsets index. Returns the csv header.
+func (dh *dataHandlerCsv) index() ([]string, error) {
+	f, err := os.Open(dh.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	reader := csv.NewReader(bufio.NewReader(f))
+	reader.Comma = dh.comma
+	reader.ReuseRecord = true
+
+	record, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the csv header: %s", err.Error())
+	}
+
+	header := append([]string{}, record...)
+	dh.offsets = []int64{reader.InputOffset()}
+
+	for {
+		if _, err := reader.Read(); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		dh.offsets = append(dh.offsets, reader.InputOffset())
+	}
+
+	return header, nil
+}
+
+// readPage reads the rows of the page number from the file, or from the file with the
+// ordered rows.
+func (dh *dataHandlerCsv) readPage(number int) ([]map[string]Value, error) {
+	min := number * dh.pageSize
+	max := min + dh.pageSize
+	if max > dh.Len() {
+		max = dh.Len()
+	}
+
+	if dh.sorted != nil {
+		return dh.sorted.read(min, max)
+	}
+
+	f, err := os.Open(dh.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	section := io.NewSectionReader(f, dh.offsets[min], dh.offsets[max]-dh.offsets[min])
+	reader := csv.NewReader(bufio.NewReader(section))
+	reader.Comma = dh.comma
+	reader.FieldsPerRecord = -1
+
+	rows := make([]map[string]Value, 0, max-min)
+	for i := min; i < max; i++ {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading the row %d: %s", i, err.Error())
+		}
+
+		row := make(map[string]Value, len(dh.positions))
+		for _, col := range dh.dataframe.columns {
+			pos := dh.positions[col.name]
+			if pos >= len(record) {
+				return nil, fmt.Errorf("the row %d hasn't the column %s", i, col.name)
+			}
+
+			if row[col.name], err = col.parseString(record[pos]); err != nil {
+				return nil, fmt.Errorf("in row %d, column %s: %s", i, col.name, err.Error())
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Get retrieves a concrete value from the DataFrame, reading it from the file if its page
+// isn't in the cache. If the row or the column is invalid then it returns an error.
+func (dh *dataHandlerCsv) Get(row int, column string) (Value, error) {
+	if row < 0 || dh.Len() <= row {
+		return Value{}, fmt.Errorf("row %d out of range", row)
+	}
+
+	if _, exists := dh.positions[column]; !exists {
+		return Value{}, fmt.Errorf("column %s not found", column)
+	}
+
+	values, err := dh.row(row)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return values[column], nil
+}
+
+// row returns the values of the row, reading its page if it isn't in the cache.
+func (dh *dataHandlerCsv) row(row int) (map[string]Value, error) {
+	number := row / dh.pageSize
+	rows, exists := dh.cache.get(number)
+	if !exists {
+		var err error
+		if rows, err = dh.readPage(number); err != nil {
+			return nil, fmt.Errorf("error reading the %s file: %s", dh.filename, err.Error())
+		}
+
+		dh.cache.put(number, rows)
+	}
+
+	return rows[row%dh.pageSize], nil
+}
+
+// Len returns the number of rows in dataframe.
+func (dh *dataHandlerCsv) Len() int {
+	return len(dh.offsets) - 1
+}
+
+// Order orders the rows using an external merge sort, so the rows don't need to fit in
+// memory. The ordered rows are stored in a temporal file.
+func (dh *dataHandlerCsv) Order() error {
+	if len(dh.dataframe.order) == 0 {
+		return nil // there isn't order defined.
+	}
+
+	sorter := newExternalSorter(
+		dh.dataframe.columns, dh.dataframe.order, dh.sortMemory, dh.tempDir)
+
+	sorted, err := sorter.sort(dh.Len(), dh.row)
+	if err != nil {
+		dh.dataframe.order = []internalOrderColumn{}
+		return fmt.Errorf("error ordering the rows: %s", err.Error())
+	}
+
+	if err := dh.Close(); err != nil {
+		sorted.remove()
+		return err
+	}
+
+	dh.sorted = sorted
+	dh.cache.clear()
+	return nil
+}
+
+// Close removes the temporal file with the ordered rows. The rows return to the file order.
+func (dh *dataHandlerCsv) Close() error {
+	if dh.sorted == nil {
+		return nil
+	}
+
+	err := dh.sorted.remove()
+	dh.sorted = nil
+	dh.cache.clear()
+	return err
+}
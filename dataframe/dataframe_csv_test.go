@@ -0,0 +1,114 @@
+package dataframe
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCsvTestFile writes the content in a temporal csv file and returns its name.
+func writeCsvTestFile(t *testing.T, content string) string {
+	filename := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		assert.FailNow(t, "error writing the file", "error: %s", err.Error())
+	}
+
+	return filename
+}
+
+// makeCsvContent returns a csv with n rows and the columns id, name and price.
+func makeCsvContent(n int) string {
+	var buf strings.Builder
+	buf.WriteString("id,name,price\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, "%d,\"name %d\nline\",%d.5\n", i, i, i)
+	}
+
+	return buf.String()
+}
+
+func Test_NewDataFrameFromCsvFile_func(t *testing.T) {
+	as := assert.New(t)
+	filename := writeCsvTestFile(t, makeCsvContent(100))
+
+	df, err := NewDataFrameFromCsvFile(filename, CsvOptions{
+		Schema:     []ColumnSchema{{Name: "price", Type: FLOAT}, {Name: "id", Type: INT}},
+		PageSize:   7,
+		CachePages: 2,
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"price", "id"}, df.Headers(), "the headers are wrong")
+	as.Equal(100, df.NumberRows(), "the number of rows is wrong")
+
+	values, err := df.ColumnAsIntRange("id", 10, 20)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]int64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, values, "the values are wrong")
+
+	handler := df.handler.(*dataHandlerCsv)
+	as.Equal(2, handler.cache.lru.Len(), "the cache size is wrong")
+
+	sum, err := df.Aggregate(NewSumAggregator("price"))
+	as.Nil(err, "there is an error in the function")
+	f, _ := sum.Float64()
+	as.Equal(float64(99*100/2)+50, f, "the sum is wrong")
+
+	row, _ := df.Iterator().Next()
+	v, _ := row.Cell("price")
+	as.Equal("0.5", v.String(), "the value is wrong")
+
+	// the rows are ordered in a temporal file.
+	err = df.Order(OrderColumn{"id", DESC})
+	as.Nil(err, "there is an error in the function")
+	values, _ = df.ColumnAsIntRange("id", 0, 3)
+	as.Equal([]int64{99, 98, 97}, values, "the order is wrong")
+	sortedName := handler.sorted.filename
+
+	as.Nil(df.Close(), "there is an error in the function")
+	_, err = os.Stat(sortedName)
+	as.True(os.IsNotExist(err), "the temporal file must be removed")
+	values, _ = df.ColumnAsIntRange("id", 0, 3)
+	as.Equal([]int64{0, 1, 2}, values, "the rows must be in the file order")
+
+	// all columns as strings.
+	df, err = NewDataFrameFromCsvFile(filename, CsvOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"id", "name", "price"}, df.Headers(), "the headers are wrong")
+	iterator, _ := df.IteratorRange(99, 100)
+	row, _ = iterator.Next()
+	v, _ = row.Cell("name")
+	as.Equal("name 99\nline", v.String(), "the value is wrong")
+}
+
+func Test_NewDataFrameFromCsvFile_func_Error(t *testing.T) {
+	as := assert.New(t)
+
+	_, err := NewDataFrameFromCsvFile(filepath.Join(t.TempDir(), "none.csv"), CsvOptions{})
+	as.NotNil(err, "the file doesn't exist")
+
+	filename := writeCsvTestFile(t, "a;b\n1;x\n2;3\n")
+	_, err = NewDataFrameFromCsvFile(filename, CsvOptions{
+		Comma: ';', Schema: []ColumnSchema{{Name: "c", Type: INT}},
+	})
+	as.Equal("error reading the "+filename+" file: column c not found in the csv header",
+		err.Error(), "the error message doesn't match")
+
+	df, err := NewDataFrameFromCsvFile(filename, CsvOptions{
+		Comma: ';', Schema: []ColumnSchema{{Name: "b", Type: INT}},
+	})
+	as.Nil(err, "there is an error in the function")
+
+	_, err = df.handler.Get(0, "b")
+	as.Equal("error reading the "+filename+" file: in row 0, column b: x is not a valid int value",
+		err.Error(), "the error message doesn't match")
+	_, err = df.handler.Get(2, "b")
+	as.Equal("row 2 out of range", err.Error(), "the error message doesn't match")
+	_, err = df.handler.Get(0, "a")
+	as.Equal("column a not found", err.Error(), "the error message doesn't match")
+}
@@ -1,9 +1,14 @@
 package dataframe
 
 import (
+	"database/sql"
+	"encoding"
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
 // dataHasValidType checks if `data` is:
@@ -62,50 +67,294 @@ func isExportableField(sf reflect.StructField) bool {
 	return sf.PkgPath == ""
 }
 
-// NewDataFrameFromStruct creates a new DataFrame using a struct array as data.
-func NewDataFrameFromStruct(data interface{}) (*DataFrame, error) {
-	dt, err := getStructOfData(data)
+// StructOptions are the options used to map the struct fields to the DataFrame columns.
+type StructOptions struct {
+	// Separator is the string used to join the name of a nested struct field with the names
+	// of its columns. By default it is ".", so the City field of the address nested struct
+	// is stored in the address.city column.
+	Separator string
+	// AutoNames adds to the DataFrame the exported fields without colName tag, using as
+	// column name the field name in snake case: the UnitPrice field is the unit_price column.
+	// Without AutoNames, the fields whose tag has options but hasn't name use the field name
+	// as is.
+	AutoNames bool
+}
 
-	if err != nil {
-		return nil, err
+// structField is a struct field stored in a DataFrame column.
+type structField struct {
+	// DataFrame column where the field is stored.
+	col column
+	// Index sequence of the field in the struct, as used by reflect FieldByIndex.
+	path []int
+	// Type of the field. It is different to the column type when the type is overridden
+	// with the type option of the colName tag.
+	ctype columnType
+}
+
+// validTagOptions contains the valid options of the colName tag.
+var validTagOptions = map[string]bool{
+	"prefix": true, // prefix of the nested struct columns.
+	"type":   true, // column type, overriding the field type.
+	"format": true, // fmt format used to export the values.
+	"desc":   true, // column description.
+	"unit":   true, // unit of the column values.
+	"scale":  true, // number of decimals of the decimal columns.
+}
+
+// snakeCase transforms the name of a struct field in snake case: UnitPrice is unit_price and
+// HTTPServer is http_server.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var buf strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1]) && runes[i-1] != '_'
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+				unicode.IsUpper(runes[i-1])
+
+			if prevLower || nextLower {
+				buf.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		buf.WriteRune(r)
 	}
 
-	var exists bool
-	df := DataFrame{}
-	df.columns = []column{}
-	df.cIndexByName = map[string]int{}
+	return buf.String()
+}
 
-	// Generate the columns using the struct field tags.
-	for i := 0; i < dt.NumField(); i++ {
-		c := column{}
-		field := dt.Field(i)
-		fieldtag := field.Tag
-		c.index = i
-
-		if c.name, exists = fieldtag.Lookup("colName"); !exists {
-			// Whether field hasn't colName tag, then it won't add to the dataframe.
+// parseColumnTag parses the colName tag. The tag contains the column name and, optionally,
+// several options separated by commas, like `colName:"price,type=float,format=%.2f"`.
+func parseColumnTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	options := map[string]string{}
+
+	for _, opt := range parts[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) == 1 {
+			options[kv[0]] = ""
+		} else {
+			options[kv[0]] = kv[1]
+		}
+	}
+
+	return parts[0], options
+}
+
+// isNestedStruct checks if the t type, or the type pointed by t, is a struct.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// structFields returns the fields of the t struct that are stored in the DataFrame columns.
+// The fields of the embedded structs are promoted and the fields of the nested structs, with
+// the colName tag, are flattened using the opts separator.
+func structFields(t reflect.Type, opts StructOptions) ([]structField, error) {
+	if opts.Separator == "" {
+		opts.Separator = "."
+	}
+
+	fields := []structField{}
+	err := opts.collectFields(t, "", []int{}, true, map[reflect.Type]bool{}, &fields)
+	return fields, err
+}
+
+// collectFields appends to fields the fields of the t struct. prefix is the prefix of the
+// column names, path is the index sequence of the t struct and exportable indicates if the
+// t struct is accessible. visited contains the structs of the path, to detect recursive types.
+func (opts StructOptions) collectFields(
+	t reflect.Type, prefix string, path []int, exportable bool,
+	visited map[reflect.Type]bool, fields *[]structField,
+) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if visited[t] {
+		return fmt.Errorf("the struct %s is recursive", t)
+	}
+
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fpath := append(append([]int{}, path...), i)
+		tag, exists := field.Tag.Lookup("colName")
+
+		if tag == "-" {
+			continue // the field is omitted.
+		}
+
+		if !exists {
+			// The embedded structs are promoted. Any else field without colName tag won't
+			// add to the dataframe, unless the names are derived from the field names.
+			if field.Anonymous && isNestedStruct(field.Type) {
+				err := opts.collectFields(field.Type, prefix, fpath,
+					exportable && isExportableField(field), visited, fields)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if !opts.AutoNames || !isExportableField(field) {
+				continue
+			}
+		}
+
+		// The tags with options but without name, like `colName:",unit=EUR"`, use the field
+		// name. The bare empty tag keeps the empty name.
+		name, options := parseColumnTag(tag)
+		if name == "" && opts.AutoNames {
+			name = snakeCase(field.Name)
+		} else if name == "" && len(options) > 0 {
+			name = field.Name
+		}
+
+		name = prefix + name
+
+		if !exportable || !isExportableField(field) {
+			return fmt.Errorf("the column %s is unexportable", name)
+		}
+
+		for key := range options {
+			if !validTagOptions[key] {
+				return fmt.Errorf("invalid option %s in column %s", key, name)
+			}
+		}
+
+		ctype, basicType, err := getColumnTypeFromType(field.Type)
+		if err == nil {
+			col := column{name: name, ctype: ctype, index: fpath[0], basicType: basicType}
+			col.meta = ColumnMeta{
+				Format: options["format"], Description: options["desc"], Unit: options["unit"],
+			}
+
+			if scale, exists := options["scale"]; exists {
+				col.meta.Scale, err = strconv.Atoi(scale)
+				if err != nil || checkScale(col.meta.Scale) != nil {
+					return fmt.Errorf("in column %s: invalid scale %s", name, scale)
+				}
+			}
+
+			if t, exists := options["type"]; exists {
+				if col.ctype, err = getColumnTypeFromString(t); err != nil {
+					return fmt.Errorf("in column %s: %s", name, err.Error())
+				}
+			} else if !basicType {
+				// the registered custom types are rebuilt when the values are parsed.
+				col.meta.ValueType, _ = registeredTypeName(field.Type)
+			}
+
+			*fields = append(*fields, structField{col, fpath, ctype})
 			continue
 		}
 
-		if exists && !isExportableField(field) {
-			return nil, fmt.Errorf("the column %s is unexportable", c.name)
+		if !isNestedStruct(field.Type) {
+			return fmt.Errorf("in column %s: %s", name, err.Error())
 		}
 
-		if _, exists = df.cIndexByName[c.name]; exists {
-			return nil, fmt.Errorf("the column %s is duplicated", c.name)
+		// The field is a nested struct.
+		nestedPrefix := name + opts.Separator
+		if p, exists := options["prefix"]; exists {
+			nestedPrefix = prefix + p
 		}
 
-		c.ctype, c.basicType, err = getColumnTypeFromType(field.Type)
+		n := len(*fields)
+		if e := opts.collectFields(field.Type, nestedPrefix, fpath, true, visited, fields); e != nil {
+			return e
+		}
 
-		if err != nil {
-			return nil, fmt.Errorf("in column %s: %s", c.name, err.Error())
+		if n == len(*fields) {
+			// The nested struct hasn't columns.
+			return fmt.Errorf("in column %s: %s", name, err.Error())
 		}
+	}
+
+	return nil
+}
+
+// fieldByPath returns the field of the v struct in the path index sequence. Returns false if
+// some nested struct in the path is a nil ptr.
+func fieldByPath(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, index := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
 
-		df.columns = append(df.columns, c)
-		df.cIndexByName[c.name] = len(df.columns) - 1
+			v = v.Elem()
+		}
+
+		v = v.Field(index)
+	}
+
+	return v, true
+}
+
+// structColumns returns the fields of the t struct that are stored in the DataFrame columns,
+// like structFields, checking that the column names aren't duplicated.
+func structColumns(t reflect.Type, opts StructOptions) ([]structField, error) {
+	fields, err := structFields(t, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, field := range fields {
+		if names[field.col.name] {
+			return nil, fmt.Errorf("the column %s is duplicated", field.col.name)
+		}
+
+		names[field.col.name] = true
+	}
+
+	return fields, nil
+}
+
+// NewDataFrameFromStruct creates a new DataFrame using a struct array as data.
+// The fields of the embedded structs are promoted to the DataFrame, and the fields of the
+// nested structs are stored in columns named nested.field.
+func NewDataFrameFromStruct(data interface{}) (*DataFrame, error) {
+	return NewDataFrameFromStructWithOptions(data, StructOptions{})
+}
+
+// NewDataFrameFromStructWithOptions creates a new DataFrame using a struct array as data and
+// the opts options to map the struct fields.
+func NewDataFrameFromStructWithOptions(data interface{}, opts StructOptions) (*DataFrame, error) {
+	dt, err := getStructOfData(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	df := DataFrame{}
+	fields, err := structColumns(dt, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the columns using the struct field tags.
+	df.columns = []column{}
+	df.cIndexByName = map[string]int{}
+	for _, field := range fields {
+		df.columns = append(df.columns, field.col)
+		df.cIndexByName[field.col.name] = len(df.columns) - 1
+	}
+
+	if df.handler, err = newDataHandlerStruct(&df, data, fields); err != nil {
+		return nil, err
 	}
 
-	df.handler, _ = newDataHandlerStruct(&df, data)
 	df.order = []internalOrderColumn{}
 	return &df, nil
 }
@@ -128,6 +377,17 @@ func parseValue(fieldv reflect.Value, col column) (*Value, error) {
 		err   error
 	)
 
+	// fieldv is a ptr. The nil ptrs are stored in the DataFrame as null values.
+	if fieldv.Kind() == reflect.Ptr {
+		if fieldv.IsNil() {
+			return &Value{}, nil
+		}
+
+		if col.basicType {
+			fieldv = fieldv.Elem()
+		}
+	}
+
 	// fieldv should be a basic type (int, uint, float...)
 	if col.basicType {
 		switch col.ctype {
@@ -145,9 +405,9 @@ func parseValue(fieldv reflect.Value, col column) (*Value, error) {
 			//col hasn't a valid columnType
 			panic("invalid column type")
 		}
-	} else {
-		// fieldv is a struct that must implements a ValueType
-		value, err = newValue(fieldv.Interface())
+	} else if value, err = newValue(fieldv.Interface()); err != nil {
+		// fieldv isn't a ValueType, so it must be a sql nullable type or a TextMarshaler.
+		value, err = parseSpecialValue(fieldv)
 	}
 
 	if err != nil {
@@ -157,8 +417,110 @@ func parseValue(fieldv reflect.Value, col column) (*Value, error) {
 	return value, nil
 }
 
+// parseSpecialValue transforms fieldv, a database/sql nullable type or a type that implements
+// encoding.TextMarshaler, in a Value. The invalid nullable values are transformed in nulls.
+func parseSpecialValue(fieldv reflect.Value) (*Value, error) {
+	var v Value
+
+	if fieldv.Kind() == reflect.Ptr {
+		fieldv = fieldv.Elem()
+	}
+
+	switch n := fieldv.Interface().(type) {
+	case sql.NullInt64:
+		v = newIntValue(n.Int64)
+	case sql.NullInt32:
+		v = newIntValue(int64(n.Int32))
+	case sql.NullInt16:
+		v = newIntValue(int64(n.Int16))
+	case sql.NullByte:
+		v = newUintValue(uint64(n.Byte))
+	case sql.NullFloat64:
+		v = newFloatValue(n.Float64)
+	case sql.NullString:
+		v = newStringValue(n.String)
+	case sql.NullTime:
+		if !n.Valid {
+			return &Value{}, nil
+		}
+
+		return parseSpecialValue(reflect.ValueOf(n.Time))
+	default:
+		return parseTextMarshaler(fieldv)
+	}
+
+	if valid := fieldv.FieldByName("Valid"); !valid.Bool() {
+		return &Value{}, nil
+	}
+
+	return &v, nil
+}
+
+// parseTextMarshaler transforms fieldv, a type that implements encoding.TextMarshaler, in
+// a string Value.
+func parseTextMarshaler(fieldv reflect.Value) (*Value, error) {
+	if !fieldv.Type().Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		// The MarshalText function has a ptr receiver.
+		ptr := reflect.New(fieldv.Type())
+		ptr.Elem().Set(fieldv)
+		fieldv = ptr
+	}
+
+	marshaler, ok := fieldv.Interface().(encoding.TextMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("the value isn't a value type")
+	}
+
+	text, err := marshaler.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	v := newStringValue(string(text))
+	return &v, nil
+}
+
+// structRowValues returns the values of the rowSt struct stored in the columns. fields
+// contains the struct field of each column.
+func structRowValues(
+	rowSt reflect.Value, columns []column, fields []structField,
+) (map[string]Value, error) {
+	valuesRow := make(map[string]Value, len(columns))
+
+	for i, col := range columns {
+		fieldv, ok := fieldByPath(rowSt, fields[i].path)
+		if !ok {
+			// the field is in a nil nested struct.
+			valuesRow[col.name] = Value{}
+			continue
+		}
+
+		fcol := col
+		fcol.ctype = fields[i].ctype
+		value, err := parseValue(fieldv, fcol)
+		if err != nil {
+			return nil, fmt.Errorf("in column %s: %s", col.name, err.Error())
+		}
+
+		if fcol.ctype != col.ctype {
+			// the column type is overridden.
+			opts := CastOptions{Scale: col.meta.Scale}
+			if *value, err = opts.castValue(*value, col.ctype); err != nil {
+				return nil, fmt.Errorf("in column %s: %s", col.name, err.Error())
+			}
+		}
+
+		valuesRow[col.name] = *value
+	}
+
+	return valuesRow, nil
+}
+
 // newDataHandlerStruct makes a new dataHandlerStruct using the arguments as struct field.
-func newDataHandlerStruct(df *DataFrame, data interface{}) (*dataHandlerStruct, error) {
+// fields contains the struct field of each DataFrame column.
+func newDataHandlerStruct(
+	df *DataFrame, data interface{}, fields []structField,
+) (*dataHandlerStruct, error) {
 	dv := reflect.ValueOf(data)
 	dh := dataHandlerStruct{}
 	dh.dataframe = df
@@ -168,12 +530,9 @@ func newDataHandlerStruct(df *DataFrame, data interface{}) (*dataHandlerStruct,
 	}
 
 	for i := 0; i < dv.Len(); i++ {
-		rowSt := dv.Index(i)
-		valuesRow := map[string]Value{}
-
-		for _, col := range df.columns {
-			value, _ := parseValue(rowSt.Field(col.index), col)
-			valuesRow[col.name] = *value
+		valuesRow, err := structRowValues(dv.Index(i), df.columns, fields)
+		if err != nil {
+			return nil, err
 		}
 
 		dh.data = append(dh.data, valuesRow)
@@ -203,46 +562,8 @@ func (dh *dataHandlerStruct) prepareOrderFuncs() {
 	oColumns := dh.dataframe.order
 	dh.orderFuncs = []func(a, b Value) (Comparers, error){}
 
-	for _, oc := range oColumns {
-		var f func(a, b Value) (Comparers, error)
-
-		switch oc.column.ctype {
-		case INT:
-			f = func(a, b Value) (Comparers, error) {
-				i, _ := a.IntType()
-				v, _ := b.Int64()
-				return i.Compare(v), nil
-			}
-
-		case UINT:
-			f = func(a, b Value) (Comparers, error) {
-				i, _ := a.UintType()
-				v, _ := b.Uint64()
-				return i.Compare(v), nil
-			}
-
-		case FLOAT:
-			f = func(a, b Value) (Comparers, error) {
-				i, _ := a.FloatType()
-				v, _ := b.Float64()
-				return i.Compare(v), nil
-			}
-
-		case COMPLEX:
-			f = func(a, b Value) (Comparers, error) {
-				i, _ := a.ComplexType()
-				v, _ := b.Complex128()
-				return i.Compare(v), nil
-			}
-		case STRING:
-			f = func(a, b Value) (Comparers, error) {
-				i, _ := a.StringType()
-				v, _ := b.Str()
-				return i.Compare(v), nil
-			}
-		}
-
-		dh.orderFuncs = append(dh.orderFuncs, f)
+	for i := range oColumns {
+		dh.orderFuncs = append(dh.orderFuncs, oColumns[i].valueComparer())
 	}
 }
 
@@ -263,23 +584,10 @@ func (dh *dataHandlerStruct) Less(i, j int) bool {
 		ocol := dh.dataframe.order[indx]
 		valuei, _ := dh.Get(i, ocol.column.name)
 		valuej, _ := dh.Get(j, ocol.column.name)
-		comp, _ := f(valuei, valuej)
-
-		switch comp {
-		case EQUAL:
-			continue
-		case LESS:
-			if ocol.order == ASC {
-				return true
-			}
-
-			return false
-		case GREAT:
-			if ocol.order == ASC {
-				return false
-			}
+		comp, _ := ocol.compare(f, valuei, valuej)
 
-			return true
+		if comp != EQUAL {
+			return comp == LESS
 		}
 	}
 
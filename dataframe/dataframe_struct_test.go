@@ -1,11 +1,41 @@
 package dataframe
 
 import (
+	"database/sql"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 )
 
+// textEnum is an enum that implements encoding.TextMarshaler, with a ptr receiver.
+type textEnum int
+
+func (e *textEnum) MarshalText() ([]byte, error) {
+	switch *e {
+	case 0:
+		return []byte("inactive"), nil
+	case 1:
+		return []byte("active"), nil
+	default:
+		return nil, errors.New("invalid enum")
+	}
+}
+
+func (e *textEnum) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "inactive":
+		*e = 0
+	case "active":
+		*e = 1
+	default:
+		return errors.New("invalid enum")
+	}
+
+	return nil
+}
+
 func Test_dataHasValid_func(t *testing.T) {
 	as := assert.New(t)
 
@@ -208,7 +238,7 @@ func Test_parseValue_func(t *testing.T) {
 	}
 
 	for ct, value := range data {
-		col := column{"test", ct, 0, true}
+		col := column{"test", ct, 0, true, ColumnMeta{}}
 		valueObj, err := parseValue(reflect.ValueOf(value), col)
 
 		if err != nil {
@@ -245,7 +275,7 @@ func Test_parseValue_func(t *testing.T) {
 	}
 
 	for ct, value := range data {
-		col := column{"test", ct, 0, false}
+		col := column{"test", ct, 0, false, ColumnMeta{}}
 		valueObj, err := parseValue(reflect.ValueOf(value), col)
 
 		if err != nil {
@@ -369,6 +399,83 @@ func Test_NewDataFrameFromStruct_func_dataHandler(t *testing.T) {
 	as.Equal(df, dhs.dataframe, "the memory address is different")
 }
 
+func Test_parseValue_func_Ptr(t *testing.T) {
+	as := assert.New(t)
+	i := 3
+	var nilInt *int
+
+	// ptr to basic type.
+	value, err := parseValue(
+		reflect.ValueOf(&i), column{"test", INT, 0, true, ColumnMeta{}})
+	as.Nil(err, "there an error in parse value")
+	n, _ := value.Int()
+	as.Equal(3, n, "the value isn't match")
+
+	// nil ptrs are null values.
+	value, err = parseValue(
+		reflect.ValueOf(nilInt), column{"test", INT, 0, true, ColumnMeta{}})
+	as.Nil(err, "there an error in parse value")
+	as.True(value.IsNull(), "the value must be null")
+
+	// ptr to custom type.
+	value, err = parseValue(
+		reflect.ValueOf(&simpleIntType{4}), column{"test", INT, 0, false, ColumnMeta{}})
+	as.Nil(err, "there an error in parse value")
+	n, _ = value.Int()
+	as.Equal(4, n, "the value isn't match")
+
+	value, err = parseValue(
+		reflect.ValueOf((*simpleIntType)(nil)), column{"test", INT, 0, false, ColumnMeta{}})
+	as.Nil(err, "there an error in parse value")
+	as.True(value.IsNull(), "the value must be null")
+}
+
+func Test_NewDataFrameFromStruct_func_Special(t *testing.T) {
+	as := assert.New(t)
+	date := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data := []struct {
+		A sql.NullInt64   `colName:"a"`
+		B sql.NullString  `colName:"b"`
+		C sql.NullFloat64 `colName:"c"`
+		D sql.NullTime    `colName:"d"`
+		E *sql.NullByte   `colName:"e"`
+		F textEnum        `colName:"f"`
+		G *time.Time      `colName:"g"`
+	}{
+		{
+			sql.NullInt64{Int64: 1, Valid: true},
+			sql.NullString{String: "x", Valid: true},
+			sql.NullFloat64{Float64: 1.5, Valid: true},
+			sql.NullTime{Time: date, Valid: true},
+			&sql.NullByte{Byte: 7, Valid: true},
+			1,
+			&date,
+		},
+		{sql.NullInt64{}, sql.NullString{}, sql.NullFloat64{}, sql.NullTime{}, nil, 0, nil},
+	}
+
+	df, err := NewDataFrameFromStruct(data)
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	types := []columnType{INT, STRING, FLOAT, STRING, UINT, STRING, STRING}
+	for i, c := range df.columns {
+		as.Equal(types[i], c.ctype, "the column %s type is wrong", c.name)
+	}
+
+	as.Equal([][]string{
+		{"1", "x", "1.5", "2020-01-02T03:04:05Z", "7", "active", "2020-01-02T03:04:05Z"},
+		{"", "", "", "", "", "inactive", ""},
+	}, dataFrameRows(df), "the rows are wrong")
+
+	// The MarshalText errors are returned.
+	data[0].F = 5
+	_, err = NewDataFrameFromStruct(data)
+	as.Equal("in column f: Parsing value: invalid enum", err.Error(), "the error message doesn't match")
+}
+
 func Test_dataHandlerStruct_Get_func(t *testing.T) {
 	var df *DataFrame
 	as := assert.New(t)
@@ -428,11 +535,11 @@ func Test_prepareOrderFuncs_func(t *testing.T) {
 	}
 
 	df.order = []internalOrderColumn{
-		{&df.columns[0], ASC},
-		{&df.columns[1], ASC},
-		{&df.columns[2], ASC},
-		{&df.columns[3], ASC},
-		{&df.columns[4], ASC},
+		{&df.columns[0], ASC, OrderOptions{}},
+		{&df.columns[1], ASC, OrderOptions{}},
+		{&df.columns[2], ASC, OrderOptions{}},
+		{&df.columns[3], ASC, OrderOptions{}},
+		{&df.columns[4], ASC, OrderOptions{}},
 	}
 
 	// make the order function
@@ -570,19 +677,19 @@ func Test_Less_func(t *testing.T) {
 	}
 
 	// single column ASC
-	dhs.dataframe.order = []internalOrderColumn{{&dhs.dataframe.columns[0], ASC}}
+	dhs.dataframe.order = []internalOrderColumn{{&dhs.dataframe.columns[0], ASC, OrderOptions{}}}
 	dhs.prepareOrderFuncs()
 	floop(func(i, j mockData) bool { return i.A < j.A })
 
 	// single column DESC
-	dhs.dataframe.order = []internalOrderColumn{{&dhs.dataframe.columns[0], DESC}}
+	dhs.dataframe.order = []internalOrderColumn{{&dhs.dataframe.columns[0], DESC, OrderOptions{}}}
 	dhs.prepareOrderFuncs()
 	floop(func(i, j mockData) bool { return j.A < i.A })
 
 	// multiple columns ASC
 	dhs.dataframe.order = []internalOrderColumn{
-		{&dhs.dataframe.columns[0], ASC},
-		{&dhs.dataframe.columns[1], ASC},
+		{&dhs.dataframe.columns[0], ASC, OrderOptions{}},
+		{&dhs.dataframe.columns[1], ASC, OrderOptions{}},
 	}
 	dhs.prepareOrderFuncs()
 	floop(func(i, j mockData) bool {
@@ -595,8 +702,8 @@ func Test_Less_func(t *testing.T) {
 
 	// multiple columns DESC
 	dhs.dataframe.order = []internalOrderColumn{
-		{&dhs.dataframe.columns[0], DESC},
-		{&dhs.dataframe.columns[1], DESC},
+		{&dhs.dataframe.columns[0], DESC, OrderOptions{}},
+		{&dhs.dataframe.columns[1], DESC, OrderOptions{}},
 	}
 	dhs.prepareOrderFuncs()
 	floop(func(i, j mockData) bool {
@@ -623,8 +730,8 @@ func Test_dataHandlerStruct_order_func(t *testing.T) {
 
 	dhs := df.handler.(*dataHandlerStruct)
 	dhs.dataframe.order = []internalOrderColumn{
-		{&dhs.dataframe.columns[0], ASC},
-		{&dhs.dataframe.columns[1], DESC},
+		{&dhs.dataframe.columns[0], ASC, OrderOptions{}},
+		{&dhs.dataframe.columns[1], DESC, OrderOptions{}},
 	}
 	dhs.Order()
 	dataOrdered := []mockData{
@@ -641,3 +748,140 @@ func Test_dataHandlerStruct_order_func(t *testing.T) {
 		as.Equalf(r.B, bv, "the cell %d a does not match", i)
 	}
 }
+
+func Test_NewDataFrameFromStruct_func_Nested(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromStruct(makePersonData())
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"version", "name", "home.city", "home.zip", "w_city", "w_zip", "status"},
+		df.Headers(), "the headers are wrong")
+	as.Equal([][]string{
+		{"2", "ann", "Madrid", "28001", "Paris", "", "active"},
+		{"", "bob", "Rome", "", "", "", "inactive"},
+	}, dataFrameRows(df), "the rows are wrong")
+
+	// custom separator.
+	df, err = NewDataFrameFromStructWithOptions(makePersonData(), StructOptions{Separator: "_"})
+	as.Nil(err, "there is an error in the function")
+	as.Equal("home_city", df.Headers()[2], "the separator isn't used")
+
+	// errors.
+	type recursive struct {
+		A    int        `colName:"a"`
+		Next *recursive `colName:"next"`
+	}
+
+	_, err = NewDataFrameFromStruct([]recursive{})
+	as.Equal("the struct dataframe.recursive is recursive", err.Error(),
+		"the error message doesn't match")
+
+	type hidden struct {
+		A int `colName:"a"`
+	}
+
+	_, err = NewDataFrameFromStruct([]struct{ hidden }{})
+	as.Equal("the column a is unexportable", err.Error(), "the error message doesn't match")
+
+	_, err = NewDataFrameFromStruct([]struct {
+		A  int     `colName:"home.city"`
+		Ad Address `colName:"home"`
+	}{})
+	as.Equal("the column home.city is duplicated", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_parseColumnTag_func(t *testing.T) {
+	as := assert.New(t)
+
+	name, options := parseColumnTag("address,prefix=addr_,inline")
+	as.Equal("address", name, "the name is wrong")
+	as.Equal(map[string]string{"prefix": "addr_", "inline": ""}, options,
+		"the options are wrong")
+
+	name, options = parseColumnTag("a")
+	as.Equal("a", name, "the name is wrong")
+	as.Equal(map[string]string{}, options, "the options are wrong")
+}
+
+func Test_NewDataFrameFromStruct_func_TagOptions(t *testing.T) {
+	as := assert.New(t)
+
+	type product struct {
+		ID        int    `colName:"-"`
+		Name      string `colName:"name"`
+		UnitPrice int    `colName:",type=float,unit=EUR"`
+		HTTPCode  uint16
+		Internal  string `colName:"-"`
+		secret    int
+	}
+
+	data := []product{{1, "a", 10, 200, "x", 0}, {2, "b", 20, 404, "y", 0}}
+
+	df, err := NewDataFrameFromStruct(data)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"name", "UnitPrice"}, df.Headers(), "the tag without name uses the "+
+		"field name")
+
+	df, err = NewDataFrameFromStructWithOptions(data, StructOptions{AutoNames: true})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"name", "unit_price", "http_code"}, df.Headers(),
+		"the headers are wrong")
+
+	prices, err := df.ColumnAsFloat("unit_price")
+	as.Nil(err, "the type isn't overridden")
+	as.Equal([]float64{10, 20}, prices, "the values are wrong")
+	as.Equal("EUR", df.Schema()[1].Meta.Unit, "the metadata is wrong")
+
+	// export to the struct, with the overridden type.
+	rows := []product{}
+	as.Nil(df.ExportStructWithOptions(&rows, StructOptions{AutoNames: true}),
+		"there is an error in the function")
+	as.Equal([]product{{0, "a", 10, 200, "", 0}, {0, "b", 20, 404, "", 0}}, rows,
+		"the exported structs are wrong")
+
+	// the bare empty tag keeps the empty name.
+	df, err = NewDataFrameFromStruct([]struct {
+		A int `colName:""`
+	}{{1}})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{""}, df.Headers(), "the bare empty tag hasn't name")
+
+	// errors.
+	_, err = NewDataFrameFromStruct([]struct {
+		A int `colName:"a,color=red"`
+	}{})
+	as.Equal("invalid option color in column a", err.Error(), "the error message doesn't match")
+
+	_, err = NewDataFrameFromStruct([]struct {
+		A int `colName:"a,type=date"`
+	}{})
+	as.Equal("in column a: date is an invalid type", err.Error(),
+		"the error message doesn't match")
+
+	_, err = NewDataFrameFromStruct([]struct {
+		A float64 `colName:"a,type=int"`
+	}{{1.5}})
+	as.Equal("in column a: the value loses precision", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_snakeCase_func(t *testing.T) {
+	as := assert.New(t)
+	names := map[string]string{
+		"Name":       "name",
+		"UnitPrice":  "unit_price",
+		"ID":         "id",
+		"HTTPServer": "http_server",
+		"UserID":     "user_id",
+		"Address2":   "address2",
+		"Snake_Case": "snake_case",
+	}
+
+	for name, expected := range names {
+		as.Equal(expected, snakeCase(name), "the snake case of %s is wrong", name)
+	}
+}
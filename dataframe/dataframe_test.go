@@ -43,6 +43,26 @@ func makeDataFrame(data interface{}, t *testing.T) (df *DataFrame) {
 	return
 }
 
+// intPtr returns a ptr to the i int.
+func intPtr(i int) *int {
+	return &i
+}
+
+// uintPtr returns a ptr to the u uint.
+func uintPtr(u uint) *uint {
+	return &u
+}
+
+// floatPtr returns a ptr to the f float64.
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// strPtr returns a ptr to the s string.
+func strPtr(s string) *string {
+	return &s
+}
+
 func Test_getColumnByName_func(t *testing.T) {
 	var df *DataFrame
 	as := assert.New(t)
@@ -218,3 +238,34 @@ func Test_dataframe_order_func(t *testing.T) {
 		as.Equalf(r.B, bv, "the cell %d a does not match", i)
 	}
 }
+
+func Test_dataframe_concurrent_order(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	data := []mockData{}
+	for i := 0; i < 200; i++ {
+		data = append(data, mockData{i % 7, i})
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 20; i++ {
+			df.Order(OrderColumn{"a", ASC}, OrderColumn{"b", DESC})
+			df.Order(OrderColumn{"b", ASC})
+		}
+		done <- true
+	}()
+
+	for i := 0; i < 20; i++ {
+		sum, err := df.Sum("b")
+		as.Nil(err, "there is an error in the operation")
+		as.Equal(int64(19900), sum, "the sum is wrong")
+	}
+
+	<-done
+}
@@ -0,0 +1,405 @@
+package dataframe
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalScale is the max number of decimals of the decimal values.
+const maxDecimalScale = 18
+
+// roundingMode indicates how the decimal values are rounded when they lose decimals.
+type roundingMode int8
+
+// The valid rounding modes.
+const (
+	ROUND_HALF_EVEN roundingMode = 0 // to the nearest value, and the ties to the even value.
+	ROUND_HALF_UP   roundingMode = 1 // to the nearest value, and the ties away from zero.
+	ROUND_HALF_DOWN roundingMode = 2 // to the nearest value, and the ties toward zero.
+	ROUND_UP        roundingMode = 3 // away from zero.
+	ROUND_DOWN      roundingMode = 4 // toward zero (truncation).
+	ROUND_CEILING   roundingMode = 5 // toward positive infinity.
+	ROUND_FLOOR     roundingMode = 6 // toward negative infinity.
+)
+
+// check checks if the rounding mode is valid.
+func (m roundingMode) check() error {
+	if m < ROUND_HALF_EVEN || m > ROUND_FLOOR {
+		return fmt.Errorf("invalid rounding mode")
+	}
+
+	return nil
+}
+
+// checkScale checks if the scale is in the valid range.
+func checkScale(scale int) error {
+	if scale < 0 || scale > maxDecimalScale {
+		return fmt.Errorf("the scale must be in the range [0, %d]", maxDecimalScale)
+	}
+
+	return nil
+}
+
+// pow10 returns 10^n as big integer.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo returns num / den rounded with the mode. den must be positive. The second value
+// returned is true whether the division is exact.
+func roundQuo(num, den *big.Int, mode roundingMode) (*big.Int, bool) {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q, true
+	}
+
+	// away indicates if the quotient, truncated toward zero, is rounded away from zero.
+	var away bool
+	sign := num.Sign()
+
+	switch mode {
+	case ROUND_UP:
+		away = true
+	case ROUND_DOWN:
+		away = false
+	case ROUND_CEILING:
+		away = sign > 0
+	case ROUND_FLOOR:
+		away = sign < 0
+	default:
+		half := new(big.Int).Lsh(new(big.Int).Abs(r), 1).Cmp(den)
+		switch {
+		case half != 0:
+			away = half > 0
+		case mode == ROUND_HALF_UP:
+			away = true
+		case mode == ROUND_HALF_EVEN:
+			away = q.Bit(0) == 1
+		}
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+
+	return q, false
+}
+
+/*
+Decimal is a fixed-point decimal number: an integer and a scale, that is the number of
+decimals. The value is unscaled / 10^scale, so 12.50 is the integer 1250 with scale 2. The
+decimals are exact, unlike the floats, so they are used to store money. The zero value is 0.
+
+Decimal implements the DecimalType interface, so the struct fields of type Decimal are stored
+in DECIMAL columns.
+
+Example:
+	price, err := ParseDecimal("12.50")
+	total, err := price.Add(tax)
+	rounded, err := total.Round(1, ROUND_HALF_UP)
+*/
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+// NewDecimal creates a new Decimal with the value unscaled / 10^scale. Returns an error if
+// the scale isn't in the range [0, 18].
+func NewDecimal(unscaled int64, scale int) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	return Decimal{unscaled, scale}, nil
+}
+
+// newDecimalFromBig creates a new Decimal with the value unscaled / 10^scale. Returns an
+// error if the value is out of range.
+func newDecimalFromBig(unscaled *big.Int, scale int) (Decimal, error) {
+	if !unscaled.IsInt64() {
+		return Decimal{}, errCastOverflow
+	}
+
+	return Decimal{unscaled.Int64(), scale}, nil
+}
+
+// ParseDecimal parses the str string as a decimal, like "-12.50". The scale of the decimal is
+// the number of decimals of the string. Returns an error if the string isn't a valid decimal
+// or the decimal is out of range.
+func ParseDecimal(str string) (Decimal, error) {
+	digits := strings.TrimLeft(str, "+-")
+	if len(str)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("%s is not a valid decimal", str)
+	}
+
+	integer, fraction := digits, ""
+	if pos := strings.IndexByte(digits, '.'); pos != -1 {
+		integer, fraction = digits[:pos], digits[pos+1:]
+	}
+
+	if integer == "" && fraction == "" || len(fraction) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("%s is not a valid decimal", str)
+	}
+
+	for _, r := range integer + fraction {
+		if r < '0' || r > '9' {
+			return Decimal{}, fmt.Errorf("%s is not a valid decimal", str)
+		}
+	}
+
+	unscaled, _ := new(big.Int).SetString("0"+integer+fraction, 10)
+	if strings.HasPrefix(str, "-") {
+		unscaled.Neg(unscaled)
+	}
+
+	d, err := newDecimalFromBig(unscaled, len(fraction))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("the decimal %s is out of range", str)
+	}
+
+	return d, nil
+}
+
+// newDecimalFromRat returns the r rational as decimal with the scale, rounded with the mode.
+// The second value returned is true whether the decimal is exact.
+func newDecimalFromRat(r *big.Rat, scale int, mode roundingMode) (Decimal, bool, error) {
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	unscaled, exact := roundQuo(num, r.Denom(), mode)
+	d, err := newDecimalFromBig(unscaled, scale)
+	return d, exact, err
+}
+
+// Unscaled returns the integer value of the decimal, without the decimal point.
+func (d Decimal) Unscaled() int64 {
+	return d.unscaled
+}
+
+// Scale returns the number of decimals of the decimal.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// big returns the unscaled value of the decimal as big integer.
+func (d Decimal) big() *big.Int {
+	return big.NewInt(d.unscaled)
+}
+
+// rat returns the decimal as big rational.
+func (d Decimal) rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.big(), pow10(d.scale))
+}
+
+// String returns the decimal as string, with all its decimals: 12.50.
+func (d Decimal) String() string {
+	str, sign := strconv.FormatInt(d.unscaled, 10), ""
+	if d.unscaled < 0 {
+		str, sign = str[1:], "-"
+	}
+
+	if d.scale == 0 {
+		return sign + str
+	}
+
+	if len(str) <= d.scale {
+		str = strings.Repeat("0", d.scale-len(str)+1) + str
+	}
+
+	return sign + str[:len(str)-d.scale] + "." + str[len(str)-d.scale:]
+}
+
+// normalized returns the decimal as string without the trailing zeros of the decimals, so
+// the equal decimals with different scales have the same string.
+func (d Decimal) normalized() string {
+	str := d.String()
+	if d.scale == 0 {
+		return str
+	}
+
+	return strings.TrimSuffix(strings.TrimRight(str, "0"), ".")
+}
+
+// Float64 returns the nearest float to the decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Value returns the decimal. It implements the DecimalType interface.
+func (d Decimal) Value() Decimal {
+	return d
+}
+
+// Compare compares the decimal with the v decimal. The decimals can have different scales.
+func (d Decimal) Compare(v Decimal) Comparers {
+	if d.scale == v.scale {
+		return simpleIntType{d.unscaled}.Compare(v.unscaled)
+	}
+
+	return Comparers(d.rat().Cmp(v.rat()))
+}
+
+// Round returns the decimal with the scale, rounded with the mode when it loses decimals.
+// Returns an error if the scale or the mode are invalid or the result is out of range.
+func (d Decimal) Round(scale int, mode roundingMode) (Decimal, error) {
+	if err := checkScale(scale); err != nil {
+		return Decimal{}, err
+	}
+
+	if err := mode.check(); err != nil {
+		return Decimal{}, err
+	}
+
+	rounded, _, err := newDecimalFromRat(d.rat(), scale, mode)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("the decimal %s with scale %d is out of range",
+			d.String(), scale)
+	}
+
+	return rounded, nil
+}
+
+// Add returns the sum of the decimal and the v decimal. The scale of the result is the max
+// scale of both decimals. Returns an error if the sum is out of range.
+func (d Decimal) Add(v Decimal) (Decimal, error) {
+	scale := d.scale
+	if v.scale > scale {
+		scale = v.scale
+	}
+
+	sum, _, err := newDecimalFromRat(new(big.Rat).Add(d.rat(), v.rat()), scale, ROUND_DOWN)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("the sum of %s and %s is out of range",
+			d.String(), v.String())
+	}
+
+	return sum, nil
+}
+
+// decimalSum sums exactly a set of decimals. The scale of the sum is the max scale of the
+// decimals.
+type decimalSum struct {
+	sum   *big.Int
+	scale int
+	count int64
+}
+
+// newDecimalSum creates an empty decimalSum.
+func newDecimalSum() *decimalSum {
+	return &decimalSum{sum: new(big.Int)}
+}
+
+// rescale changes the scale of the sum to a greater scale.
+func (s *decimalSum) rescale(scale int) {
+	if scale > s.scale {
+		s.sum.Mul(s.sum, pow10(scale-s.scale))
+		s.scale = scale
+	}
+}
+
+// add adds the d decimal to the sum.
+func (s *decimalSum) add(d Decimal) {
+	s.rescale(d.scale)
+	s.sum.Add(s.sum, new(big.Int).Mul(d.big(), pow10(s.scale-d.scale)))
+	s.count++
+}
+
+// merge adds the other sum to the sum.
+func (s *decimalSum) merge(other *decimalSum) {
+	s.rescale(other.scale)
+	s.sum.Add(s.sum, new(big.Int).Mul(other.sum, pow10(s.scale-other.scale)))
+	s.count += other.count
+}
+
+// result returns the sum as decimal. Returns an error if it is out of range.
+func (s *decimalSum) result() (Decimal, error) {
+	return newDecimalFromBig(s.sum, s.scale)
+}
+
+// mean returns the mean of the decimals, with the scale of the sum, rounded with the mode.
+func (s *decimalSum) mean(mode roundingMode) Decimal {
+	// the mean is always in the range of the decimals, so it can't overflow.
+	q, _ := roundQuo(s.sum, big.NewInt(s.count), mode)
+	return Decimal{q.Int64(), s.scale}
+}
+
+// RoundDecimal returns a new DataFrame where the values of the colName column, type decimal,
+// are rounded to the scale with the mode. The values with less decimals are extended to the
+// scale, so all values have the same number of decimals.
+//
+// Example:
+//	// rounds the prices to cents.
+//	df, err := df.RoundDecimal("price", 2, ROUND_HALF_UP)
+func (df *DataFrame) RoundDecimal(
+	colName string, scale int, mode roundingMode,
+) (*DataFrame, error) {
+	if err := df.checkColumnIsValid(colName, DECIMAL); err != nil {
+		return nil, err
+	}
+
+	if err := checkScale(scale); err != nil {
+		return nil, err
+	}
+
+	if err := mode.check(); err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	for _, row := range data {
+		v := row[colName]
+		if v.IsNull() {
+			continue
+		}
+
+		d, _ := v.Decimal()
+		rounded, err := d.Round(scale, mode)
+		if err != nil {
+			return nil, err
+		}
+
+		row[colName] = newDecimalValue(rounded)
+	}
+
+	return newDataFrameFromData(df.columns, data), nil
+}
+
+// MeanDecimalRange returns the exact arithmetic mean of the colName column, type decimal, in
+// the range rows between min and max. The mean has the max scale of the values and it is
+// rounded with the mode. Returns an error if there aren't values.
+func (df *DataFrame) MeanDecimalRange(
+	colName string, mode roundingMode, min, max int,
+) (Decimal, error) {
+	if err := df.checkColumnIsValid(colName, DECIMAL); err != nil {
+		return Decimal{}, err
+	}
+
+	if err := mode.check(); err != nil {
+		return Decimal{}, err
+	}
+
+	agg := &meanAggregator{
+		aggregatorBase: aggregatorBase{colName: colName},
+		name:           "Mean",
+		exact:          true,
+		rounding:       mode,
+	}
+
+	value, err := df.AggregateRange(agg, min, max)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	if value.IsNull() {
+		return Decimal{}, fmt.Errorf("there aren't values to calculate the mean")
+	}
+
+	return value.Decimal()
+}
+
+// MeanDecimal returns the exact arithmetic mean of the colName column, type decimal, rounded
+// with the mode. See MeanDecimalRange.
+func (df *DataFrame) MeanDecimal(colName string, mode roundingMode) (Decimal, error) {
+	return df.MeanDecimalRange(colName, mode, 0, df.NumberRows())
+}
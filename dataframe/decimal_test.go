@@ -0,0 +1,271 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// mustDecimal parses the str decimal, failing the test if it is invalid.
+func mustDecimal(t *testing.T, str string) Decimal {
+	d, err := ParseDecimal(str)
+	if err != nil {
+		assert.FailNow(t, "error parsing the decimal", "error: %s", err.Error())
+	}
+
+	return d
+}
+
+func Test_ParseDecimal_func(t *testing.T) {
+	as := assert.New(t)
+
+	tests := []struct {
+		str      string
+		unscaled int64
+		scale    int
+		expected string
+	}{
+		{"12.50", 1250, 2, "12.50"},
+		{"-0.05", -5, 2, "-0.05"},
+		{"7", 7, 0, "7"},
+		{".5", 5, 1, "0.5"},
+		{"+3.0", 30, 1, "3.0"},
+		{"-9223372036854775808", -9223372036854775808, 0, "-9223372036854775808"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.str)
+		as.Nil(err, "there is an error parsing %s", test.str)
+		as.Equal(test.unscaled, d.Unscaled(), "the unscaled value of %s is wrong", test.str)
+		as.Equal(test.scale, d.Scale(), "the scale of %s is wrong", test.str)
+		as.Equal(test.expected, d.String(), "the string of %s is wrong", test.str)
+	}
+
+	for _, str := range []string{"", ".", "1.2.3", "abc", "--1", "1e3", "1.0000000000000000001"} {
+		_, err := ParseDecimal(str)
+		as.EqualError(err, str+" is not a valid decimal", "the error is wrong")
+	}
+
+	_, err := ParseDecimal("9223372036854775808")
+	as.EqualError(err, "the decimal 9223372036854775808 is out of range", "the error is wrong")
+
+	_, err = NewDecimal(1, 19)
+	as.EqualError(err, "the scale must be in the range [0, 18]", "the error is wrong")
+}
+
+func Test_Decimal_Round_func(t *testing.T) {
+	as := assert.New(t)
+
+	tests := []struct {
+		mode     roundingMode
+		positive string
+		negative string
+	}{
+		{ROUND_HALF_EVEN, "2.34", "-2.34"},
+		{ROUND_HALF_UP, "2.35", "-2.35"},
+		{ROUND_HALF_DOWN, "2.34", "-2.34"},
+		{ROUND_UP, "2.35", "-2.35"},
+		{ROUND_DOWN, "2.34", "-2.34"},
+		{ROUND_CEILING, "2.35", "-2.34"},
+		{ROUND_FLOOR, "2.34", "-2.35"},
+	}
+
+	for _, test := range tests {
+		d, err := mustDecimal(t, "2.345").Round(2, test.mode)
+		as.Nil(err, "there is an error in the function")
+		as.Equal(test.positive, d.String(), "the rounding %d is wrong", test.mode)
+
+		d, _ = mustDecimal(t, "-2.345").Round(2, test.mode)
+		as.Equal(test.negative, d.String(), "the rounding %d is wrong", test.mode)
+	}
+
+	d, _ := mustDecimal(t, "2.355").Round(2, ROUND_HALF_EVEN)
+	as.Equal("2.36", d.String(), "the ties are rounded to the even value")
+
+	d, _ = mustDecimal(t, "2.3451").Round(2, ROUND_HALF_DOWN)
+	as.Equal("2.35", d.String(), "the values over the half are rounded up")
+
+	d, _ = mustDecimal(t, "1.5").Round(3, ROUND_HALF_EVEN)
+	as.Equal("1.500", d.String(), "the decimals are extended")
+
+	_, err := mustDecimal(t, "1.5").Round(2, roundingMode(9))
+	as.EqualError(err, "invalid rounding mode", "the error is wrong")
+
+	_, err = mustDecimal(t, "9223372036854775807").Round(1, ROUND_HALF_EVEN)
+	as.EqualError(err, "the decimal 9223372036854775807 with scale 1 is out of range",
+		"the error is wrong")
+}
+
+func Test_Decimal_Compare_Add(t *testing.T) {
+	as := assert.New(t)
+
+	as.Equal(EQUAL, mustDecimal(t, "1.5").Compare(mustDecimal(t, "1.50")), "the values are equal")
+	as.Equal(LESS, mustDecimal(t, "-2").Compare(mustDecimal(t, "1.99")), "the value is less")
+	as.Equal(GREAT, mustDecimal(t, "0.3").Compare(mustDecimal(t, "0.29")), "the value is greater")
+
+	a, b := newDecimalValue(mustDecimal(t, "1.5")), newDecimalValue(mustDecimal(t, "1.50"))
+	as.True(a.Equal(b), "the equal decimals with different scales are equal")
+	as.Equal(a.Hash(), b.Hash(), "the hashes are wrong")
+	as.Equal(DECIMAL, a.Kind(), "the kind is wrong")
+
+	sum, err := mustDecimal(t, "0.1").Add(mustDecimal(t, "0.20"))
+	as.Nil(err, "there is an error in the function")
+	as.Equal("0.30", sum.String(), "the sum is wrong")
+
+	_, err = mustDecimal(t, "9223372036854775807").Add(mustDecimal(t, "1"))
+	as.EqualError(err, "the sum of 9223372036854775807 and 1 is out of range",
+		"the error is wrong")
+}
+
+// decimalRow is the struct used in the decimal tests.
+type decimalRow struct {
+	Item  string   `colName:"item"`
+	Price Decimal  `colName:"price"`
+	Tax   *Decimal `colName:"tax"`
+}
+
+func Test_DataFrame_Decimal_Operations(t *testing.T) {
+	as := assert.New(t)
+	tax := mustDecimal(t, "0.21")
+
+	df, err := NewDataFrameFromStruct([]decimalRow{
+		{"a", mustDecimal(t, "0.10"), &tax},
+		{"b", mustDecimal(t, "0.20"), nil},
+		{"c", mustDecimal(t, "0.1"), &tax},
+		{"d", mustDecimal(t, "-1.05"), nil},
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal(DECIMAL, df.Schema()[1].Type, "the column type is wrong")
+	as.Equal(DECIMAL, df.Schema()[2].Type, "the column type is wrong")
+
+	sum, err := df.Aggregate(NewSumAggregator("price"))
+	as.Nil(err, "there is an error in the function")
+	as.Equal("-0.65", sum.String(), "the sum is exact")
+
+	sum, _ = df.Aggregate(NewSumAggregator("tax"))
+	as.Equal("0.42", sum.String(), "the null values are ignored")
+
+	mean, err := df.Mean("price")
+	as.Nil(err, "there is an error in the function")
+	as.InDelta(-0.1625, mean, 1e-12, "the mean is wrong")
+
+	meanValue, err := df.Aggregate(NewMeanAggregator("price"))
+	as.Nil(err, "there is an error in the function")
+	as.Equal(FLOAT, meanValue.Kind(), "the mean of a decimal column is a float")
+
+	d, err := df.MeanDecimal("price", ROUND_HALF_EVEN)
+	as.Nil(err, "there is an error in the function")
+	as.Equal("-0.16", d.String(), "the mean is wrong")
+
+	d, _ = df.MeanDecimal("price", ROUND_FLOOR)
+	as.Equal("-0.17", d.String(), "the mean is wrong")
+
+	max, err := df.Max("price")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(mustDecimal(t, "0.20"), max, "the max is wrong")
+
+	as.Nil(df.Order(OrderColumn{"price", DESC}, OrderColumn{"item", ASC}), "error ordering")
+	as.Equal([]string{"b", "a", "c", "d"}, columnStrings(df, "item"), "the order is wrong")
+
+	rounded, err := df.RoundDecimal("price", 1, ROUND_HALF_UP)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"0.2", "0.1", "0.1", "-1.1"}, columnStrings(rounded, "price"),
+		"the values are wrong")
+
+	// overflow.
+	big, _ := NewDataFrameFromStruct([]decimalRow{
+		{"a", mustDecimal(t, "92233720368547758.07"), nil},
+		{"b", mustDecimal(t, "0.01"), nil},
+	})
+
+	_, err = big.Aggregate(NewSumAggregator("price"))
+	as.EqualError(err, "the sum of the column price overflows the type decimal",
+		"the error is wrong")
+
+	sum, err = big.Aggregate(NewSumAggregatorWithOptions("price",
+		SumOptions{Overflow: SUM_OVERFLOW_FLOAT}))
+	as.Nil(err, "there is an error in the function")
+	f, _ := sum.Float64()
+	as.Equal(92233720368547758.08, f, "the sum is a float")
+
+	_, err = df.RoundDecimal("item", 1, ROUND_HALF_UP)
+	as.EqualError(err, "column item is not type decimal", "the error is wrong")
+}
+
+func Test_DataFrame_Decimal_Cast(t *testing.T) {
+	as := assert.New(t)
+
+	type priceRow struct {
+		Price float64 `colName:"price"`
+		Net   float64 `colName:"net,type=decimal,scale=2"`
+	}
+
+	df, err := NewDataFrameFromStruct([]priceRow{{0.1, 12.5}, {2.675, 3}})
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"12.50", "3.00"}, columnStrings(df, "net"), "the values are wrong")
+
+	_, err = df.Cast("price", DECIMAL, CastOptions{Scale: 2})
+	as.EqualError(err, "error casting the value 2.675 of the column price to decimal: "+
+		"the value loses precision", "the error is wrong")
+
+	cast, err := df.Cast("price", DECIMAL, CastOptions{
+		Scale: 2, PrecisionLoss: CAST_SATURATE, Rounding: ROUND_HALF_UP,
+	})
+
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"0.10", "2.68"}, columnStrings(cast, "price"), "the values are wrong")
+
+	str, err := cast.Cast("price", STRING, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"0.10", "2.68"}, columnStrings(str, "price"), "the values are wrong")
+
+	back, err := str.Cast("price", DECIMAL, CastOptions{Scale: 3})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"0.100", "2.680"}, columnStrings(back, "price"), "the values are wrong")
+
+	f, err := cast.Cast("price", FLOAT, CastOptions{})
+	as.Nil(err, "there is an error in the function")
+	values, _ := f.ColumnAsFloat("price")
+	as.Equal([]float64{0.1, 2.68}, values, "the values are wrong")
+
+	_, err = df.Cast("price", DECIMAL, CastOptions{Scale: 20})
+	as.EqualError(err, "the scale must be in the range [0, 18]", "the error is wrong")
+}
+
+func Test_DataFrame_Decimal_Csv(t *testing.T) {
+	as := assert.New(t)
+	filename := writeCsvTestFile(t, "item,price\na,10.5\nb,3\nc,\nd,-0.25\n")
+	schema := []ColumnSchema{
+		{Name: "item", Type: STRING},
+		{Name: "price", Type: DECIMAL, Meta: ColumnMeta{Scale: 2}},
+	}
+
+	df, err := NewDataFrameFromCsvFile(filename, CsvOptions{Schema: schema})
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	as.Equal([]string{"10.50", "3.00", "", "-0.25"}, columnStrings(df, "price"),
+		"the values are wrong")
+
+	// the rows are ordered in a temporal file.
+	as.Nil(df.Order(OrderColumn{"price", ASC}), "there is an error ordering the rows")
+	as.Equal([]string{"-0.25", "3.00", "10.50", ""}, columnStrings(df, "price"),
+		"the order is wrong")
+	as.Nil(df.Close(), "there is an error in the function")
+
+	filename = writeCsvTestFile(t, "item,price\na,10.505\n")
+	df, _ = NewDataFrameFromCsvFile(filename, CsvOptions{Schema: schema})
+	_, err = df.handler.Get(0, "price")
+	as.EqualError(err, "error reading the "+filename+" file: in row 0, column price: "+
+		"10.505 has more than 2 decimals", "the error is wrong")
+
+	_, err = ParseValue(DECIMAL, "1,5")
+	as.EqualError(err, "1,5 is not a valid decimal value", "the error is wrong")
+}
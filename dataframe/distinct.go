@@ -0,0 +1,206 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// keepType indicates which row is kept when the duplicated rows are dropped.
+type keepType int8
+
+// The valid keep types.
+const (
+	KEEP_FIRST keepType = 0 // keeps the first row of the duplicated rows.
+	KEEP_LAST  keepType = 1 // keeps the last row of the duplicated rows.
+	KEEP_NONE  keepType = 2 // drops all duplicated rows.
+)
+
+// selectColumns returns the info of the names columns. If names is empty, it returns all
+// columns. Returns an error if some column doesn't exist or it is duplicated.
+func (df *DataFrame) selectColumns(names []string) ([]column, error) {
+	if len(names) == 0 {
+		return append([]column{}, df.columns...), nil
+	}
+
+	columns := []column{}
+	selected := map[string]bool{}
+	for _, name := range names {
+		col, exists := df.getColumnByName(name)
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", name)
+		}
+
+		if selected[name] {
+			return nil, fmt.Errorf("the column %s is duplicated", name)
+		}
+
+		selected[name] = true
+		columns = append(columns, *col)
+	}
+
+	return columns, nil
+}
+
+// rowKeys returns the key of each data row, made with the values of the columns.
+// Two rows have the same key whether they have equal values in all columns.
+func rowKeys(data []map[string]Value, columns []column) []string {
+	keys := make([]string, len(data))
+	values := make([]Value, len(columns))
+
+	for i, row := range data {
+		for j, col := range columns {
+			values[j] = row[col.name]
+		}
+
+		keys[i] = valuesKey(values)
+	}
+
+	return keys
+}
+
+// Duplicated returns an array with a bool for each DataFrame row, indicating if the row is
+// a duplicate of a previous row. Two rows are duplicated whether they have equal values in the
+// cols columns. If cols is empty it uses all columns. The null values are equal between them.
+func (df *DataFrame) Duplicated(cols ...string) ([]bool, error) {
+	columns, err := df.selectColumns(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := rowKeys(df.snapshot(), columns)
+	seen := map[string]bool{}
+	mask := make([]bool, len(keys))
+
+	for i, key := range keys {
+		mask[i] = seen[key]
+		seen[key] = true
+	}
+
+	return mask, nil
+}
+
+// DropDuplicates returns a new DataFrame without the duplicated rows. Two rows are duplicated
+// whether they have equal values in the cols columns. If cols is empty it uses all columns.
+// The keep param indicates which of the duplicated rows is kept: KEEP_FIRST, KEEP_LAST or
+// KEEP_NONE. The rows keep the current order.
+func (df *DataFrame) DropDuplicates(cols []string, keep keepType) (*DataFrame, error) {
+	columns, err := df.selectColumns(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	if keep != KEEP_FIRST && keep != KEEP_LAST && keep != KEEP_NONE {
+		return nil, fmt.Errorf("invalid keep type")
+	}
+
+	data := df.snapshot()
+	keys := rowKeys(data, columns)
+	count := map[string]int{}
+	last := map[string]int{}
+
+	for i, key := range keys {
+		count[key]++
+		last[key] = i
+	}
+
+	newData := []map[string]Value{}
+	seen := map[string]bool{}
+
+	for i, key := range keys {
+		switch {
+		case keep == KEEP_FIRST && !seen[key],
+			keep == KEEP_LAST && last[key] == i,
+			keep == KEEP_NONE && count[key] == 1:
+			newData = append(newData, data[i])
+		}
+
+		seen[key] = true
+	}
+
+	return newDataFrameFromData(df.columns, newData), nil
+}
+
+// Distinct returns a new DataFrame with the cols columns and the distinct rows of these
+// columns, in order of appearance. If cols is empty it uses all columns.
+func (df *DataFrame) Distinct(cols ...string) (*DataFrame, error) {
+	columns, err := df.selectColumns(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	keys := rowKeys(data, columns)
+	seen := map[string]bool{}
+	newData := []map[string]Value{}
+
+	for i, key := range keys {
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		row := map[string]Value{}
+		for _, col := range columns {
+			row[col.name] = data[i][col.name]
+		}
+
+		newData = append(newData, row)
+	}
+
+	return newDataFrameFromData(columns, newData), nil
+}
+
+// ValueCounts returns a new DataFrame with the distinct values of the colName column and the
+// number of rows of each value, in the "count" column, type int. The rows are sorted by the
+// count, in descending order, and the values with the same count in order of appearance.
+// The null values are ignored.
+func (df *DataFrame) ValueCounts(colName string) (*DataFrame, error) {
+	col, exists := df.getColumnByName(colName)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	if colName == "count" {
+		return nil, fmt.Errorf("the column count is duplicated")
+	}
+
+	values := newDistinctValues()
+	counts := []int64{}
+
+	for _, row := range df.snapshot() {
+		v := row[colName]
+		if v.IsNull() {
+			continue
+		}
+
+		pos := values.add(v)
+		if pos == len(counts) {
+			counts = append(counts, 0)
+		}
+
+		counts[pos]++
+	}
+
+	positions := make([]int, len(counts))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		return counts[positions[i]] > counts[positions[j]]
+	})
+
+	data := make([]map[string]Value, len(positions))
+	for i, pos := range positions {
+		data[i] = map[string]Value{
+			colName: values.values[pos],
+			"count": newIntValue(counts[pos]),
+		}
+	}
+
+	columns := []column{
+		{name: colName, ctype: col.ctype, basicType: true, meta: col.meta},
+		{name: "count", ctype: INT, basicType: true},
+	}
+	return newDataFrameFromData(columns, data), nil
+}
@@ -0,0 +1,139 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// makeDuplicatedDataFrame makes the DataFrame used in the distinct tests.
+func makeDuplicatedDataFrame(t *testing.T) *DataFrame {
+	one, two := 1, 2
+	data := []struct {
+		A string `colName:"a"`
+		B *int   `colName:"b"`
+		C int    `colName:"c"`
+	}{
+		{"x", &one, 1},
+		{"y", &two, 2},
+		{"x", &one, 3},
+		{"z", nil, 4},
+		{"y", &one, 5},
+		{"z", nil, 6},
+	}
+
+	return makeDataFrame(data, t)
+}
+
+func Test_DataFrame_Duplicated(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeDuplicatedDataFrame(t); df == nil {
+		return
+	}
+
+	mask, err := df.Duplicated("a", "b")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, false, true, false, false, true}, mask, "the mask is wrong")
+
+	mask, _ = df.Duplicated("a")
+	as.Equal([]bool{false, false, true, false, true, true}, mask, "the mask is wrong")
+
+	mask, _ = df.Duplicated()
+	as.Equal([]bool{false, false, false, false, false, false}, mask, "the mask is wrong")
+
+	_, err = df.Duplicated("x")
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_DropDuplicates(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeDuplicatedDataFrame(t); df == nil {
+		return
+	}
+
+	result, err := df.DropDuplicates([]string{"a", "b"}, KEEP_FIRST)
+	as.Nil(err, "there is an error in the function")
+	values, _ := result.ColumnAsInt("c")
+	as.Equal([]int64{1, 2, 4, 5}, values, "the rows are wrong")
+
+	result, _ = df.DropDuplicates([]string{"a", "b"}, KEEP_LAST)
+	values, _ = result.ColumnAsInt("c")
+	as.Equal([]int64{2, 3, 5, 6}, values, "the rows are wrong")
+
+	result, _ = df.DropDuplicates([]string{"a", "b"}, KEEP_NONE)
+	values, _ = result.ColumnAsInt("c")
+	as.Equal([]int64{2, 5}, values, "the rows are wrong")
+	as.Equal(df.Headers(), result.Headers(), "the headers are wrong")
+
+	_, err = df.DropDuplicates(nil, 5)
+	as.Equal("invalid keep type", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Distinct(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeDuplicatedDataFrame(t); df == nil {
+		return
+	}
+
+	result, err := df.Distinct("b", "a")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"b", "a"}, result.Headers(), "the headers are wrong")
+	as.Equal([][]string{{"1", "x"}, {"2", "y"}, {"", "z"}, {"1", "y"}},
+		dataFrameRows(result), "the rows are wrong")
+
+	result, _ = df.Distinct()
+	as.Equal(6, result.NumberRows(), "the number of rows is wrong")
+
+	_, err = df.Distinct("x")
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+	_, err = df.Distinct("a", "a")
+	as.Equal("the column a is duplicated", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Distinct_Zeros(t *testing.T) {
+	as := assert.New(t)
+	negZero := math.Copysign(0, -1)
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"f": []float64{0, negZero, 1, negZero},
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	// -0 and +0 are the same value.
+	mask, err := df.Duplicated("f")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, true, false, true}, mask, "the mask is wrong")
+
+	result, _ := df.ValueCounts("f")
+	as.Equal([][]string{{"0", "3"}, {"1", "1"}}, dataFrameRows(result), "the rows are wrong")
+}
+
+func Test_DataFrame_ValueCounts(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeDuplicatedDataFrame(t); df == nil {
+		return
+	}
+
+	result, err := df.ValueCounts("b")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"b", "count"}, result.Headers(), "the headers are wrong")
+	as.Equal([][]string{{"1", "3"}, {"2", "1"}}, dataFrameRows(result), "the rows are wrong")
+
+	result, _ = df.ValueCounts("a")
+	as.Equal([][]string{{"x", "2"}, {"y", "2"}, {"z", "2"}}, dataFrameRows(result),
+		"the rows are wrong")
+
+	_, err = df.ValueCounts("x")
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+}
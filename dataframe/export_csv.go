@@ -43,6 +43,7 @@ func (e *ErrorCsvFile) Error() string {
 }
 
 // ExportCsvFile exports the DataFrame rows in the f file as a csv file, using the conf config.
+// The values are formatted with the format of the column metadata, if it is defined.
 func (df *DataFrame) ExportCsvFile(f *os.File, conf *CsvConfig) error {
 	if len(conf.Columns) == 0 {
 		err := fmt.Errorf("in csv config, the Columns string array is empty")
@@ -57,7 +58,10 @@ func (df *DataFrame) ExportCsvFile(f *os.File, conf *CsvConfig) error {
 	}
 
 	// make the iterator
-	iterator, err := df.IteratorRange(conf.Range.Min, conf.Range.Max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	iterator, err := df.lockedIteratorRange(conf.Range.Min, conf.Range.Max)
 	if err != nil {
 		return &ErrorCsvFile{f.Name(), err}
 	}
@@ -78,7 +82,8 @@ func (df *DataFrame) ExportCsvFile(f *os.File, conf *CsvConfig) error {
 
 		for _, colName := range conf.Columns {
 			value, _ := row.Cell(colName)
-			csvRow = append(csvRow, value.String())
+			col, _ := df.getColumnByName(colName)
+			csvRow = append(csvRow, col.formatValue(value))
 		}
 
 		if err := writer.Write(csvRow); err != nil {
@@ -312,3 +312,32 @@ func Test_DataFrame_exportCsvFileDefault_func(t *testing.T) {
 
 	checkCsv(f.Name(), df, &config, t)
 }
+
+func Test_DataFrame_exportCsvFile_func_Format(t *testing.T) {
+	var f *os.File
+	as := assert.New(t)
+
+	if f = createCsvFile(t, "test1_format.csv", false); f == nil {
+		return
+	}
+
+	defer closeAndRemoveFile(t, f)
+
+	df, err := NewDataFrameFromStruct([]struct {
+		Name  string   `colName:"name"`
+		Price *float64 `colName:"price,format=%.2f,unit=EUR"`
+		Units int      `colName:"units,format=%03d"`
+	}{{"a", new(float64), 7}, {"b", nil, 12}})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	if err = df.ExportCsvFileDefault(f); err != nil {
+		as.FailNowf("error exporting csv file", "error: %s", err.Error())
+	}
+
+	content, _ := os.ReadFile(f.Name())
+	as.Equal("name;price;units\na;0.00;007\nb;;012\n", string(content),
+		"the csv content is wrong")
+}
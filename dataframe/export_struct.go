@@ -0,0 +1,214 @@
+package dataframe
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ExportStruct exports the DataFrame rows in dst, a ptr to a struct slice. The struct fields
+// are mapped to the DataFrame columns like in NewDataFrameFromStruct, and the fields whose
+// column doesn't exist in the DataFrame are left with the zero value.
+//
+// Example:
+//	rows := []myDataFrame{}
+//	err := df.ExportStruct(&rows)
+func (df *DataFrame) ExportStruct(dst interface{}) error {
+	return df.ExportStructWithOptions(dst, StructOptions{})
+}
+
+// ExportStructWithOptions exports the DataFrame rows in dst, a ptr to a struct slice, using
+// the opts options to map the struct fields.
+func (df *DataFrame) ExportStructWithOptions(dst interface{}, opts StructOptions) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("invalid data type. Valid type: slice ptr")
+	}
+
+	st := dv.Elem().Type().Elem()
+	if st.Kind() != reflect.Struct {
+		return fmt.Errorf("the data type is not a struct")
+	}
+
+	allFields, err := structFields(st, opts)
+	if err != nil {
+		return err
+	}
+
+	// fields contains only the struct fields of the DataFrame columns.
+	fields := []structField{}
+	for _, field := range allFields {
+		col, exists := df.getColumnByName(field.col.name)
+		if !exists {
+			continue
+		}
+
+		// the category columns are exported in the string fields.
+		fromCategory := col.ctype == CATEGORY && field.ctype == STRING
+		if col.ctype != field.col.ctype && col.ctype != field.ctype && !fromCategory {
+			return fmt.Errorf("the column %s is type %s, but the field is type %s",
+				col.name, col.ctype, field.col.ctype)
+		}
+
+		fields = append(fields, field)
+	}
+
+	data := df.snapshot()
+	rows := reflect.MakeSlice(dv.Elem().Type(), len(data), len(data))
+
+	for i, row := range data {
+		for _, field := range fields {
+			value := row[field.col.name]
+			if value.Kind() != field.ctype && !value.IsNull() {
+				// the column type is overridden in the field tag.
+				opts := CastOptions{Scale: field.col.meta.Scale}
+				if value, err = opts.castValue(value, field.ctype); err != nil {
+					return fmt.Errorf("in column %s: %s", field.col.name, err.Error())
+				}
+			}
+
+			if value.IsNull() {
+				continue // the zero value is the null value.
+			}
+
+			if err := setFieldValue(fieldByPathAlloc(rows.Index(i), field.path), value); err != nil {
+				return fmt.Errorf("in column %s: %s", field.col.name, err.Error())
+			}
+		}
+	}
+
+	dv.Elem().Set(rows)
+	return nil
+}
+
+// fieldByPathAlloc returns the field of the v struct in the path index sequence. The nil
+// ptrs to nested structs in the path are allocated.
+func fieldByPathAlloc(v reflect.Value, path []int) reflect.Value {
+	for i, index := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(index)
+	}
+
+	return v
+}
+
+// setFieldValue stores the value, that isn't null, in the fieldv struct field.
+func setFieldValue(fieldv reflect.Value, value Value) error {
+	rv := reflect.ValueOf(value.value)
+
+	// The field has the type of the value.
+	if rv.Type().AssignableTo(fieldv.Type()) {
+		fieldv.Set(rv)
+		return nil
+	}
+
+	if rv.Kind() == reflect.Ptr && rv.Elem().Type().AssignableTo(fieldv.Type()) {
+		fieldv.Set(rv.Elem())
+		return nil
+	}
+
+	if fieldv.Kind() == reflect.Ptr {
+		ptr := reflect.New(fieldv.Type().Elem())
+		if err := setFieldValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+
+		fieldv.Set(ptr)
+		return nil
+	}
+
+	if ok, err := setSqlNullValue(fieldv, value); ok {
+		return err
+	}
+
+	switch p := fieldv.Addr().Interface().(type) {
+	case ValueParser:
+		return p.Parse(value.String())
+	case encoding.TextUnmarshaler:
+		return p.UnmarshalText([]byte(value.String()))
+	}
+
+	switch fieldv.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		i, err := value.Int64()
+		if err != nil {
+			return err
+		}
+
+		if fieldv.OverflowInt(i) {
+			return fmt.Errorf("the value %d overflows the field type %s", i, fieldv.Type())
+		}
+
+		fieldv.SetInt(i)
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		u, err := value.Uint64()
+		if err != nil {
+			return err
+		}
+
+		if fieldv.OverflowUint(u) {
+			return fmt.Errorf("the value %d overflows the field type %s", u, fieldv.Type())
+		}
+
+		fieldv.SetUint(u)
+	case reflect.Float64, reflect.Float32:
+		f, err := value.Float64()
+		fieldv.SetFloat(f)
+		return err
+	case reflect.Complex128, reflect.Complex64:
+		c, err := value.Complex128()
+		fieldv.SetComplex(c)
+		return err
+	case reflect.String:
+		str, err := value.Str()
+		fieldv.SetString(str)
+		return err
+	default:
+		return fmt.Errorf("the value can't be stored in the field type %s", fieldv.Type())
+	}
+
+	return nil
+}
+
+// setSqlNullValue stores the value in the fieldv field, if it is a database/sql nullable type.
+// Returns false if the field isn't a nullable type.
+func setSqlNullValue(fieldv reflect.Value, value Value) (bool, error) {
+	var err error
+
+	switch n := fieldv.Addr().Interface().(type) {
+	case *sql.NullInt64:
+		n.Int64, err = value.Int64()
+		n.Valid = true
+	case *sql.NullInt32:
+		n.Int32, err = value.Int32()
+		n.Valid = true
+	case *sql.NullInt16:
+		n.Int16, err = value.Int16()
+		n.Valid = true
+	case *sql.NullByte:
+		n.Byte, err = value.Uint8()
+		n.Valid = true
+	case *sql.NullFloat64:
+		n.Float64, err = value.Float64()
+		n.Valid = true
+	case *sql.NullString:
+		n.String, err = value.Str()
+		n.Valid = true
+	case *sql.NullTime:
+		n.Time, err = time.Parse(time.RFC3339Nano, value.String())
+		n.Valid = true
+	default:
+		return false, nil
+	}
+
+	return true, err
+}
@@ -0,0 +1,109 @@
+package dataframe
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Address is an struct nested in personData.
+type Address struct {
+	City    string  `colName:"city"`
+	Zip     *uint16 `colName:"zip"`
+	Country string
+}
+
+// Audit is an struct embedded in personData.
+type Audit struct {
+	Version sql.NullInt64 `colName:"version"`
+}
+
+// personData is the struct used in the export struct tests.
+type personData struct {
+	Audit
+	Name    string   `colName:"name"`
+	Home    Address  `colName:"home"`
+	Work    *Address `colName:"work,prefix=w_"`
+	Status  textEnum `colName:"status"`
+	Ignored int
+}
+
+func makePersonData() []personData {
+	zip := uint16(28001)
+	return []personData{
+		{
+			Audit{sql.NullInt64{Int64: 2, Valid: true}}, "ann",
+			Address{"Madrid", &zip, "ES"}, &Address{"Paris", nil, "FR"}, 1, 3,
+		},
+		{Audit{}, "bob", Address{"Rome", nil, "IT"}, nil, 0, 4},
+	}
+}
+
+func Test_DataFrame_ExportStruct(t *testing.T) {
+	as := assert.New(t)
+
+	data := makePersonData()
+	df, err := NewDataFrameFromStruct(data)
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	rows := []personData{}
+	as.Nil(df.ExportStruct(&rows), "there is an error in the function")
+
+	// The fields without column aren't exported.
+	for i := range data {
+		data[i].Ignored = 0
+		data[i].Home.Country = ""
+		if data[i].Work != nil {
+			data[i].Work.Country = ""
+		}
+	}
+
+	as.Equal(data, rows, "the exported structs are wrong")
+
+	// Export to other struct.
+	type otherStruct struct {
+		Name string  `colName:"name"`
+		City *string `colName:"city"`
+		Zip  uint16  `colName:"home.zip"`
+	}
+
+	others := []otherStruct{}
+	as.Nil(df.ExportStruct(&others), "there is an error in the function")
+	as.Equal([]otherStruct{{"ann", nil, 28001}, {"bob", nil, 0}}, others,
+		"the exported structs are wrong")
+
+	// errors.
+	err = df.ExportStruct(others)
+	as.Equal("invalid data type. Valid type: slice ptr", err.Error(),
+		"the error message doesn't match")
+	err = df.ExportStruct(&[]int{})
+	as.Equal("the data type is not a struct", err.Error(), "the error message doesn't match")
+	err = df.ExportStruct(&[]struct {
+		Name int `colName:"name"`
+	}{})
+	as.Equal("the column name is type string, but the field is type int", err.Error(),
+		"the error message doesn't match")
+
+	small := []struct {
+		Zip int8 `colName:"home.zip"`
+	}{}
+	err = df.ExportStruct(&small)
+	as.Equal("the column home.zip is type uint, but the field is type int", err.Error(),
+		"the error message doesn't match")
+
+	enums := []struct {
+		Status int `colName:"status"`
+	}{}
+	err = df.ExportStruct(&enums)
+	as.Equal("the column status is type string, but the field is type int", err.Error(),
+		"the error message doesn't match")
+
+	tiny := []struct {
+		Zip uint8 `colName:"home.zip"`
+	}{}
+	err = df.ExportStruct(&tiny)
+	as.Equal("in column home.zip: the value 28001 overflows the field type uint8", err.Error(),
+		"the error message doesn't match")
+}
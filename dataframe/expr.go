@@ -0,0 +1,767 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// exprBool is the type of the boolean expressions. There aren't boolean columns, so the
+// boolean expressions can be used in the Query function, but they can't be stored in a column.
+const exprBool columnType = "bool"
+
+// exprNode is a node of a parsed expression. The type of the node is checked when it is
+// built, so the evaluation only fails with the errors that depend on the values.
+type exprNode interface {
+	// etype returns the type of the node result: INT, UINT, FLOAT, STRING or exprBool.
+	etype() columnType
+	// eval returns the result of the node in the row. The result is an int64, uint64,
+	// float64, string or bool, depending of the node type, or nil if it is null.
+	eval(row map[string]Value) (interface{}, error)
+}
+
+// isNumericExpr returns true whether the expression type is a number.
+func isNumericExpr(etype columnType) bool {
+	return etype == INT || etype == UINT || etype == FLOAT
+}
+
+// exprLiteral is a literal value of the expression.
+type exprLiteral struct {
+	value interface{}
+	ctype columnType
+}
+
+func (n *exprLiteral) etype() columnType {
+	return n.ctype
+}
+
+func (n *exprLiteral) eval(row map[string]Value) (interface{}, error) {
+	return n.value, nil
+}
+
+// exprColumn is the value of a column of the row. The decimal columns are read as floats and
+// the category columns as strings.
+type exprColumn struct {
+	name  string
+	ctype columnType
+}
+
+// newExprColumn creates the node of the name column. Returns an error if the column doesn't
+// exist or its type can't be used in the expressions.
+func (df *DataFrame) newExprColumn(name string) (exprNode, error) {
+	col, exists := df.getColumnByName(name)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", name)
+	}
+
+	if col.ctype == COMPLEX {
+		return nil, fmt.Errorf("the column %s of type %s can't be used in an expression",
+			name, col.ctype)
+	}
+
+	return &exprColumn{name, col.ctype}, nil
+}
+
+func (n *exprColumn) etype() columnType {
+	switch n.ctype {
+	case DECIMAL:
+		return FLOAT
+	case CATEGORY:
+		return STRING
+	default:
+		return n.ctype
+	}
+}
+
+func (n *exprColumn) eval(row map[string]Value) (interface{}, error) {
+	v := row[n.name]
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	switch n.ctype {
+	case INT:
+		return v.Int64()
+	case UINT:
+		return v.Uint64()
+	case FLOAT:
+		return v.Float64()
+	case DECIMAL:
+		d, err := v.Decimal()
+		return d.Float64(), err
+	default:
+		return v.Str()
+	}
+}
+
+// exprLogic is the && or || operator. The null values follow the three-valued logic of SQL:
+// false && null is false, true || null is true and the rest of operations with null are null.
+type exprLogic struct {
+	op   string
+	x, y exprNode
+}
+
+// newExprLogic creates the node of the x op y logic operation. Returns an error if the
+// operands aren't bool.
+func newExprLogic(op string, x, y exprNode) (exprNode, error) {
+	if x.etype() != exprBool || y.etype() != exprBool {
+		return nil, fmt.Errorf("the operator %s is invalid between %s and %s",
+			op, x.etype(), y.etype())
+	}
+
+	return &exprLogic{op, x, y}, nil
+}
+
+func (n *exprLogic) etype() columnType {
+	return exprBool
+}
+
+func (n *exprLogic) eval(row map[string]Value) (interface{}, error) {
+	// the result when some operand has this value.
+	decisive := n.op == "||"
+
+	x, err := n.x.eval(row)
+	if err != nil || x == decisive {
+		return x, err
+	}
+
+	y, err := n.y.eval(row)
+	if err != nil || y == decisive {
+		return y, err
+	}
+
+	if x == nil || y == nil {
+		return nil, nil
+	}
+
+	return !decisive, nil
+}
+
+// exprNot is the negation of a bool expression.
+type exprNot struct {
+	x exprNode
+}
+
+// newExprNot creates the node of the negation of x. Returns an error if x isn't bool.
+func newExprNot(x exprNode) (exprNode, error) {
+	if x.etype() != exprBool {
+		return nil, fmt.Errorf("the operator ! is invalid with %s", x.etype())
+	}
+
+	return &exprNot{x}, nil
+}
+
+func (n *exprNot) etype() columnType {
+	return exprBool
+}
+
+func (n *exprNot) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	return !x.(bool), nil
+}
+
+// exprIsNull checks if the result of the x expression is null. The result is never null.
+type exprIsNull struct {
+	x   exprNode
+	not bool
+}
+
+func (n *exprIsNull) etype() columnType {
+	return exprBool
+}
+
+func (n *exprIsNull) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	return (x == nil) != n.not, err
+}
+
+// checkComparable returns an error if the op operator can't compare the x and y types. The
+// numbers are compared between them, the strings between them, and the bools only with the
+// equality operators.
+func checkComparable(op string, x, y columnType) error {
+	switch {
+	case isNumericExpr(x) && isNumericExpr(y), x == STRING && y == STRING:
+		return nil
+	case x == exprBool && y == exprBool && (op == "==" || op == "!="):
+		return nil
+	}
+
+	return fmt.Errorf("the operator %s is invalid between %s and %s", op, x, y)
+}
+
+// compareExprValues compares the a and b values, that aren't null and have comparable types.
+// Returns -1, 0 or 1 whether a is less, equal or greater than b. The second value is false
+// if the values are unordered, because some of them is NaN.
+func compareExprValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string)), true
+	case bool:
+		if a == b.(bool) {
+			return 0, true
+		}
+
+		return 1, true
+	}
+
+	_, af := a.(float64)
+	_, bf := b.(float64)
+	if af || bf {
+		fa, fb := exprFloat(a), exprFloat(b)
+		if math.IsNaN(fa) || math.IsNaN(fb) {
+			return 0, false
+		}
+
+		return int(compareFloats(fa, fb)), true
+	}
+
+	// integers: the negative ints are less than all uints.
+	ia, aint := a.(int64)
+	ib, bint := b.(int64)
+	switch {
+	case aint && bint:
+		return int(simpleIntType{ia}.Compare(ib)), true
+	case aint && ia < 0:
+		return -1, true
+	case bint && ib < 0:
+		return 1, true
+	}
+
+	return int(simpleUintType{exprUint(a)}.Compare(exprUint(b))), true
+}
+
+// exprFloat converts the v number to float64.
+func exprFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return v.(float64)
+	}
+}
+
+// exprUint converts the v integer, that isn't negative, to uint64.
+func exprUint(v interface{}) uint64 {
+	if i, ok := v.(int64); ok {
+		return uint64(i)
+	}
+
+	return v.(uint64)
+}
+
+// exprInt converts the v integer to int64. Returns an error if it overflows the type.
+func exprInt(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return v, nil
+	default:
+		if u := v.(uint64); u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+
+		return 0, fmt.Errorf("the value %d overflows the type int", v)
+	}
+}
+
+// exprCompare is a comparison between the x and y values. The result is null whether some of
+// the values is null.
+type exprCompare struct {
+	op   string
+	x, y exprNode
+}
+
+// newExprCompare creates the node of the x op y comparison. Returns an error if the types
+// can't be compared.
+func newExprCompare(op string, x, y exprNode) (exprNode, error) {
+	if err := checkComparable(op, x.etype(), y.etype()); err != nil {
+		return nil, err
+	}
+
+	return &exprCompare{op, x, y}, nil
+}
+
+func (n *exprCompare) etype() columnType {
+	return exprBool
+}
+
+func (n *exprCompare) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	y, err := n.y.eval(row)
+	if err != nil || y == nil {
+		return nil, err
+	}
+
+	c, ordered := compareExprValues(x, y)
+	if !ordered {
+		// the NaN floats are only different.
+		return n.op == "!=", nil
+	}
+
+	switch n.op {
+	case "==":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+// exprIn checks if the x value is equal to some value of the list. Like in SQL, the result is
+// null whether x is null, or it isn't found and some value of the list is null.
+type exprIn struct {
+	x    exprNode
+	list []exprNode
+	not  bool
+}
+
+// newExprIn creates the node of the x in list operation. Returns an error if some value of
+// the list can't be compared with x.
+func newExprIn(x exprNode, list []exprNode, not bool) (exprNode, error) {
+	for _, node := range list {
+		if err := checkComparable("in", x.etype(), node.etype()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &exprIn{x, list, not}, nil
+}
+
+func (n *exprIn) etype() columnType {
+	return exprBool
+}
+
+func (n *exprIn) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	null := false
+	for _, node := range n.list {
+		y, err := node.eval(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if y == nil {
+			null = true
+		} else if c, ordered := compareExprValues(x, y); ordered && c == 0 {
+			return !n.not, nil
+		}
+	}
+
+	if null {
+		return nil, nil
+	}
+
+	return n.not, nil
+}
+
+// exprArithmetic is an arithmetic operation between the x and y numbers, or the concatenation
+// of two strings. The result is null whether some of the values is null.
+type exprArithmetic struct {
+	op    string
+	x, y  exprNode
+	ctype columnType
+}
+
+// newExprArithmetic creates the node of the x op y arithmetic operation. The result is float
+// whether some operand is float, uint whether both operands are uint, and int in the rest of
+// cases. The + operator concatenates the strings too. Returns an error if the operator is
+// invalid with the types.
+func newExprArithmetic(op string, x, y exprNode) (exprNode, error) {
+	xt, yt := x.etype(), y.etype()
+
+	switch {
+	case op == "+" && xt == STRING && yt == STRING:
+		return &exprArithmetic{op, x, y, STRING}, nil
+	case !isNumericExpr(xt) || !isNumericExpr(yt), op == "%" && (xt == FLOAT || yt == FLOAT):
+		return nil, fmt.Errorf("the operator %s is invalid between %s and %s", op, xt, yt)
+	case xt == FLOAT || yt == FLOAT:
+		return &exprArithmetic{op, x, y, FLOAT}, nil
+	case xt == UINT && yt == UINT:
+		return &exprArithmetic{op, x, y, UINT}, nil
+	default:
+		return &exprArithmetic{op, x, y, INT}, nil
+	}
+}
+
+func (n *exprArithmetic) etype() columnType {
+	return n.ctype
+}
+
+func (n *exprArithmetic) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	y, err := n.y.eval(row)
+	if err != nil || y == nil {
+		return nil, err
+	}
+
+	switch n.ctype {
+	case STRING:
+		return x.(string) + y.(string), nil
+	case FLOAT:
+		return floatOperation(n.op, exprFloat(x), exprFloat(y)), nil
+	case UINT:
+		return uintOperation(n.op, x.(uint64), y.(uint64))
+	}
+
+	a, err := exprInt(x)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := exprInt(y)
+	if err != nil {
+		return nil, err
+	}
+
+	return intOperation(n.op, a, b)
+}
+
+// floatOperation returns the result of the a op b operation.
+func floatOperation(op string, a, b float64) float64 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	default:
+		return a / b
+	}
+}
+
+// intOperation returns the result of the a op b operation. The division is the integer
+// division. Returns an error if the result overflows the type or b is 0 in a division.
+func intOperation(op string, a, b int64) (interface{}, error) {
+	var r int64
+	overflow := false
+
+	switch op {
+	case "+":
+		r = a + b
+		overflow = (a > 0 && b > 0 && r < 0) || (a < 0 && b < 0 && r >= 0)
+	case "-":
+		r = a - b
+		overflow = (a >= 0 && b < 0 && r < 0) || (a < 0 && b > 0 && r >= 0)
+	case "*":
+		r = a * b
+		overflow = a != 0 && (r/a != b || (a == -1 && b == math.MinInt64))
+	default:
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+
+		if op == "/" {
+			r = a / b
+			overflow = a == math.MinInt64 && b == -1
+		} else {
+			r = a % b
+		}
+	}
+
+	if overflow {
+		return nil, fmt.Errorf("the operation %d %s %d overflows the type int", a, op, b)
+	}
+
+	return r, nil
+}
+
+// uintOperation returns the result of the a op b operation. The division is the integer
+// division. Returns an error if the result overflows the type or b is 0 in a division.
+func uintOperation(op string, a, b uint64) (interface{}, error) {
+	var r uint64
+	overflow := false
+
+	switch op {
+	case "+":
+		r = a + b
+		overflow = r < a
+	case "-":
+		r = a - b
+		overflow = a < b
+	case "*":
+		r = a * b
+		overflow = a != 0 && r/a != b
+	default:
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+
+		if op == "/" {
+			r = a / b
+		} else {
+			r = a % b
+		}
+	}
+
+	if overflow {
+		return nil, fmt.Errorf("the operation %d %s %d overflows the type uint", a, op, b)
+	}
+
+	return r, nil
+}
+
+// exprNeg is the negation of a number. The negation of an uint is an int.
+type exprNeg struct {
+	x exprNode
+}
+
+// newExprNeg creates the node of the negation of x. Returns an error if x isn't a number.
+func newExprNeg(x exprNode) (exprNode, error) {
+	if !isNumericExpr(x.etype()) {
+		return nil, fmt.Errorf("the operator - is invalid with %s", x.etype())
+	}
+
+	return &exprNeg{x}, nil
+}
+
+func (n *exprNeg) etype() columnType {
+	if n.x.etype() == UINT {
+		return INT
+	}
+
+	return n.x.etype()
+}
+
+func (n *exprNeg) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.x.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case float64:
+		return -x, nil
+	case int64:
+		return intOperation("-", 0, x)
+	}
+
+	// -(MaxInt64 + 1) is the only negation of an uint greater than MaxInt64 that is an int.
+	if u := x.(uint64); u <= 1<<63 {
+		return int64(-u), nil
+	}
+
+	return nil, fmt.Errorf("the operation -%d overflows the type int", x)
+}
+
+// exprCall is a call to one of the functions of the expressions:
+//
+//	abs(number): the absolute value of the number, with the same type.
+//	len(string): the number of characters of the string.
+//	lower(string), upper(string): the string in lower or upper case.
+//
+// The result is null whether the argument is null.
+type exprCall struct {
+	name  string
+	arg   exprNode
+	ctype columnType
+}
+
+// newExprCall creates the node of the call to the name function. Returns an error if the
+// function doesn't exist or the arguments are invalid.
+func newExprCall(name string, args []exprNode) (exprNode, error) {
+	var valid func(etype columnType) bool
+	ctype := STRING
+
+	switch name {
+	case "abs":
+		valid, ctype = isNumericExpr, ""
+	case "len", "lower", "upper":
+		valid = func(etype columnType) bool { return etype == STRING }
+		if name == "len" {
+			ctype = INT
+		}
+	default:
+		return nil, fmt.Errorf("the function %s doesn't exist", name)
+	}
+
+	if len(args) != 1 {
+		return nil, fmt.Errorf("the function %s has 1 argument, not %d", name, len(args))
+	}
+
+	if !valid(args[0].etype()) {
+		return nil, fmt.Errorf("the function %s is invalid with %s", name, args[0].etype())
+	}
+
+	if ctype == "" {
+		ctype = args[0].etype()
+	}
+
+	return &exprCall{name, args[0], ctype}, nil
+}
+
+func (n *exprCall) etype() columnType {
+	return n.ctype
+}
+
+func (n *exprCall) eval(row map[string]Value) (interface{}, error) {
+	x, err := n.arg.eval(row)
+	if err != nil || x == nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case string:
+		switch n.name {
+		case "len":
+			return int64(utf8.RuneCountInString(x)), nil
+		case "lower":
+			return strings.ToLower(x), nil
+		default:
+			return strings.ToUpper(x), nil
+		}
+	case int64:
+		if x < 0 {
+			return intOperation("-", 0, x)
+		}
+	case float64:
+		return math.Abs(x), nil
+	}
+
+	return x, nil
+}
+
+// evalQuery returns an array with a bool for each row of data, that indicates if the row
+// matches the str expression. data is a snapshot of the DataFrame rows.
+func (df *DataFrame) evalQuery(data []map[string]Value, str string) ([]bool, error) {
+	node, err := df.parseExpr(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.etype() != exprBool {
+		return nil, fmt.Errorf("the expression must be type bool, not %s", node.etype())
+	}
+
+	mask := make([]bool, len(data))
+	for i, row := range data {
+		result, err := node.eval(row)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating the row %d: %s", i, err.Error())
+		}
+
+		mask[i] = result == true
+	}
+
+	return mask, nil
+}
+
+/*
+Query returns a new DataFrame with the rows that match the str expression, in the current
+order. The rows where the expression is null don't match it.
+
+The expressions are made of:
+	- Columns: by their name. The names that aren't identifiers, or are keywords, are written
+	  between backquotes: `unit price`.
+	- Literals: integers (10), floats (1.5, 2e3), strings ('ES' or "ES") and true or false.
+	- Arithmetic: + - * / % and the negation. The integer division discards the decimals, and
+	  the + operator concatenates the strings.
+	- Comparisons: == != < <= > >=. The numbers can be compared between them, the strings
+	  between them and the bools with == and !=.
+	- Lists: x in (a, b, ...) and x not in (a, b, ...).
+	- Null checks: x is null and x is not null.
+	- Boolean logic: && (or and), || (or or) and ! (or not).
+	- Functions: abs(number), len(string), lower(string) and upper(string).
+
+The operations with integers are int, or uint whether both operands are uint, and float if
+some operand is float. The decimal columns are float in the expressions, the category columns
+are string and the complex columns can't be used. The operations with null values return null,
+except the boolean operators, that follow the three-valued logic of SQL.
+
+The expression is parsed and type-checked with the DataFrame columns before evaluating it.
+Returns an error if the expression is invalid, its type isn't bool, or the evaluation of some
+row fails: a division by zero or an integer overflow.
+
+Example:
+	df, err := df.Query("price > 10 && country in ('ES', 'PT') && discount is null")
+*/
+func (df *DataFrame) Query(str string) (*DataFrame, error) {
+	data := df.snapshot()
+	mask, err := df.evalQuery(data, str)
+	if err != nil {
+		return nil, err
+	}
+
+	newData := []map[string]Value{}
+	for i, row := range data {
+		if mask[i] {
+			newData = append(newData, row)
+		}
+	}
+
+	return newDataFrameFromData(df.columns, newData), nil
+}
+
+// QueryMask returns an array with a bool for each DataFrame row, indicating if the row matches
+// the str expression. See Query for the syntax of the expressions.
+func (df *DataFrame) QueryMask(str string) ([]bool, error) {
+	return df.evalQuery(df.snapshot(), str)
+}
+
+/*
+Eval returns a new DataFrame with the newColName column, that contains the result of the str
+expression in each row. The type of the column is the type of the expression: int, uint,
+float or string. See Query for the syntax of the expressions. Returns an error if the
+expression is invalid, its type is bool, or the evaluation of some row fails.
+
+Example:
+	df, err := df.Eval("total", "price * quantity - abs(discount)")
+*/
+func (df *DataFrame) Eval(newColName, str string) (*DataFrame, error) {
+	node, err := df.parseExpr(str)
+	if err != nil {
+		return nil, err
+	}
+
+	ctype := node.etype()
+	if ctype == exprBool {
+		return nil, fmt.Errorf("the expression type bool can't be stored in a column")
+	}
+
+	data := df.snapshot()
+	values := make([]Value, len(data))
+	for i, row := range data {
+		result, err := node.eval(row)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating the row %d: %s", i, err.Error())
+		}
+
+		switch result := result.(type) {
+		case int64:
+			values[i] = newIntValue(result)
+		case uint64:
+			values[i] = newUintValue(result)
+		case float64:
+			values[i] = newFloatValue(result)
+		case string:
+			values[i] = newStringValue(result)
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, ctype, values)
+}
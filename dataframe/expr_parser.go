@@ -0,0 +1,479 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// exprTokenKind is the kind of a token of an expression.
+type exprTokenKind int8
+
+// The kinds of the expression tokens.
+const (
+	tokenEOF exprTokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenOperator
+)
+
+// exprToken is a token of an expression.
+type exprToken struct {
+	kind exprTokenKind
+	// text is the text of the token in the expression, or the content of the string tokens.
+	text string
+	// pos is the position of the token in the expression, starting at 1.
+	pos int
+	// quoted flag indicates if the identifier is between backquotes, so it isn't a keyword.
+	quoted bool
+}
+
+// describe returns the description of the token used in the error messages.
+func (t exprToken) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return t.text
+	}
+}
+
+// is returns true whether the token is the op operator or the keyword, without case.
+func (t exprToken) is(keyword string) bool {
+	if t.kind == tokenOperator {
+		return t.text == keyword
+	}
+
+	return t.kind == tokenIdent && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// exprOperators contains the operators of the expressions. The two characters operators are
+// before, so they are found first.
+var exprOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "%", "(", ")", ",", "!",
+}
+
+// exprSyntaxError returns the error of a syntax error in the pos position.
+func exprSyntaxError(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at position %d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+// tokenizeExpr splits the str expression in tokens. The last token is always tokenEOF.
+func tokenizeExpr(str string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	i := 0
+
+	for i < len(str) {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(str) {
+				r, size = utf8.DecodeRuneInString(str[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+
+				i += size
+			}
+
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: str[start:i], pos: start + 1})
+			continue
+		case r >= '0' && r <= '9' || r == '.' && i+1 < len(str) && isDigit(str[i+1]):
+			token, err := scanExprNumber(str, start)
+			if err != nil {
+				return nil, err
+			}
+
+			i += len(token.text)
+			tokens = append(tokens, token)
+			continue
+		case r == '\'' || r == '"' || r == '`':
+			text, end, err := scanExprQuoted(str, start)
+			if err != nil {
+				return nil, err
+			}
+
+			kind := tokenString
+			if r == '`' {
+				kind = tokenIdent
+			}
+
+			i = end
+			tokens = append(tokens, exprToken{kind, text, start + 1, r == '`'})
+			continue
+		}
+
+		found := false
+		for _, op := range exprOperators {
+			if strings.HasPrefix(str[i:], op) {
+				tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: start + 1})
+				i += len(op)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, exprSyntaxError(start+1, "invalid character %q", r)
+		}
+	}
+
+	return append(tokens, exprToken{kind: tokenEOF, pos: len(str) + 1}), nil
+}
+
+// scanExprNumber returns the token of the number that begins in the start position of str.
+// The numbers with decimal point or exponent are floats.
+func scanExprNumber(str string, start int) (exprToken, error) {
+	i, kind := start, tokenInt
+	for i < len(str) && isDigit(str[i]) {
+		i++
+	}
+
+	if i < len(str) && str[i] == '.' {
+		kind = tokenFloat
+		for i++; i < len(str) && isDigit(str[i]); i++ {
+		}
+	}
+
+	if i < len(str) && (str[i] == 'e' || str[i] == 'E') {
+		j := i + 1
+		if j < len(str) && (str[j] == '+' || str[j] == '-') {
+			j++
+		}
+
+		if j < len(str) && isDigit(str[j]) {
+			kind = tokenFloat
+			for i = j; i < len(str) && isDigit(str[i]); i++ {
+			}
+		}
+	}
+
+	token := exprToken{kind: kind, text: str[start:i], pos: start + 1}
+	if kind == tokenInt {
+		if _, err := strconv.ParseInt(token.text, 10, 64); err != nil {
+			return token, exprSyntaxError(token.pos, "the number %s is out of range", token.text)
+		}
+	}
+
+	return token, nil
+}
+
+// scanExprQuoted returns the content of the quoted text that begins in the start position of
+// str, and the position after the closing quote. The backslash escapes the quote, the
+// backslash and the \n, \r and \t characters.
+func scanExprQuoted(str string, start int) (string, int, error) {
+	quote := str[start]
+	text := strings.Builder{}
+
+	for i := start + 1; i < len(str); i++ {
+		switch c := str[i]; {
+		case c == quote:
+			return text.String(), i + 1, nil
+		case c != '\\':
+			text.WriteByte(c)
+		case i+1 == len(str):
+			return "", 0, exprSyntaxError(start+1, "the text isn't closed")
+		default:
+			i++
+			switch str[i] {
+			case 'n':
+				text.WriteByte('\n')
+			case 'r':
+				text.WriteByte('\r')
+			case 't':
+				text.WriteByte('\t')
+			case '\\', '\'', '"', '`':
+				text.WriteByte(str[i])
+			default:
+				return "", 0, exprSyntaxError(i, "invalid escape \\%c", str[i])
+			}
+		}
+	}
+
+	return "", 0, exprSyntaxError(start+1, "the text isn't closed")
+}
+
+// exprParser is a recursive descent parser that builds the nodes of an expression. The
+// nodes are type-checked with the columns of the DataFrame while they are built.
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	df     *DataFrame
+}
+
+// parseExpr parses the str expression and returns its root node. Returns an error if the
+// expression has a syntax error, a column doesn't exist or the types are invalid.
+func (df *DataFrame) parseExpr(str string) (exprNode, error) {
+	tokens, err := tokenizeExpr(str)
+	if err != nil {
+		return nil, err
+	}
+
+	p := exprParser{tokens: tokens, df: df}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.kind != tokenEOF {
+		return nil, exprSyntaxError(token.pos, "unexpected %s", token.describe())
+	}
+
+	return node, nil
+}
+
+// peek returns the current token.
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next returns the current token and moves to the next one.
+func (p *exprParser) next() exprToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+
+	return token
+}
+
+// accept moves to the next token whether the current token is one of the keywords.
+// Returns the keyword found, or an empty string.
+func (p *exprParser) accept(keywords ...string) string {
+	for _, keyword := range keywords {
+		if p.peek().is(keyword) {
+			p.next()
+			return keyword
+		}
+	}
+
+	return ""
+}
+
+// expect moves to the next token. Returns an error if the current token isn't the op operator.
+func (p *exprParser) expect(op string) error {
+	if token := p.next(); !token.is(op) {
+		return exprSyntaxError(token.pos, "expected %s, found %s", op, token.describe())
+	}
+
+	return nil
+}
+
+// parseOr parses: and { ("||" | "or") and }.
+func (p *exprParser) parseOr() (exprNode, error) {
+	x, err := p.parseAnd()
+	for err == nil && p.accept("||", "or") != "" {
+		var y exprNode
+		if y, err = p.parseAnd(); err == nil {
+			x, err = newExprLogic("||", x, y)
+		}
+	}
+
+	return x, err
+}
+
+// parseAnd parses: not { ("&&" | "and") not }.
+func (p *exprParser) parseAnd() (exprNode, error) {
+	x, err := p.parseNot()
+	for err == nil && p.accept("&&", "and") != "" {
+		var y exprNode
+		if y, err = p.parseNot(); err == nil {
+			x, err = newExprLogic("&&", x, y)
+		}
+	}
+
+	return x, err
+}
+
+// parseNot parses: ("!" | "not") not | comparison.
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.accept("!", "not") == "" {
+		return p.parseComparison()
+	}
+
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	return newExprNot(x)
+}
+
+// parseComparison parses: additive [ op additive | ["not"] "in" list | "is" ["not"] "null" ].
+func (p *exprParser) parseComparison() (exprNode, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if op := p.accept("==", "!=", "<=", ">=", "<", ">"); op != "" {
+		y, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		return newExprCompare(op, x, y)
+	}
+
+	if p.accept("is") != "" {
+		not := p.accept("not") != ""
+		if token := p.next(); !token.is("null") {
+			return nil, exprSyntaxError(token.pos, "expected null, found %s", token.describe())
+		}
+
+		return &exprIsNull{x, not}, nil
+	}
+
+	not := false
+	if p.peek().is("not") && p.tokens[p.pos+1].is("in") {
+		p.next()
+		not = true
+	}
+
+	if p.accept("in") == "" {
+		return x, nil
+	}
+
+	list, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+
+	return newExprIn(x, list, not)
+}
+
+// parseList parses: "(" or { "," or } ")".
+func (p *exprParser) parseList() ([]exprNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	list := []exprNode{}
+	for {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, node)
+		if p.accept(",") == "" {
+			return list, p.expect(")")
+		}
+	}
+}
+
+// parseAdditive parses: multiplicative { ("+" | "-") multiplicative }.
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	x, err := p.parseMultiplicative()
+	for op := ""; err == nil; {
+		if op = p.accept("+", "-"); op == "" {
+			break
+		}
+
+		var y exprNode
+		if y, err = p.parseMultiplicative(); err == nil {
+			x, err = newExprArithmetic(op, x, y)
+		}
+	}
+
+	return x, err
+}
+
+// parseMultiplicative parses: unary { ("*" | "/" | "%") unary }.
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	x, err := p.parseUnary()
+	for op := ""; err == nil; {
+		if op = p.accept("*", "/", "%"); op == "" {
+			break
+		}
+
+		var y exprNode
+		if y, err = p.parseUnary(); err == nil {
+			x, err = newExprArithmetic(op, x, y)
+		}
+	}
+
+	return x, err
+}
+
+// parseUnary parses: "-" unary | primary.
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.accept("-") == "" {
+		return p.parsePrimary()
+	}
+
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return newExprNeg(x)
+}
+
+// parsePrimary parses a literal, a column, a function call or an expression in parentheses.
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	token := p.next()
+
+	switch {
+	case token.kind == tokenInt:
+		i, _ := strconv.ParseInt(token.text, 10, 64)
+		return &exprLiteral{i, INT}, nil
+	case token.kind == tokenFloat:
+		f, _ := strconv.ParseFloat(token.text, 64)
+		return &exprLiteral{f, FLOAT}, nil
+	case token.kind == tokenString:
+		return &exprLiteral{token.text, STRING}, nil
+	case token.is("true"), token.is("false"):
+		return &exprLiteral{token.is("true"), exprBool}, nil
+	case token.is("("):
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return node, p.expect(")")
+	case token.kind == tokenIdent && !token.quoted && p.peek().is("("):
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+
+		return newExprCall(strings.ToLower(token.text), args)
+	case token.kind == tokenIdent && (token.quoted || !isExprKeyword(token.text)):
+		return p.df.newExprColumn(token.text)
+	}
+
+	return nil, exprSyntaxError(token.pos, "unexpected %s", token.describe())
+}
+
+// parseArgs parses the arguments of a function call: "(" [ or { "," or } ] ")".
+func (p *exprParser) parseArgs() ([]exprNode, error) {
+	if p.tokens[p.pos+1].is(")") {
+		p.pos += 2
+		return []exprNode{}, nil
+	}
+
+	return p.parseList()
+}
+
+// isExprKeyword returns true whether the str identifier is a keyword of the expressions.
+// The columns with these names must be between backquotes.
+func isExprKeyword(str string) bool {
+	switch strings.ToLower(str) {
+	case "and", "or", "not", "in", "is", "null", "true", "false":
+		return true
+	}
+
+	return false
+}
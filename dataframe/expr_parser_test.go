@@ -0,0 +1,118 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_tokenizeExpr_func(t *testing.T) {
+	as := assert.New(t)
+
+	tokens, err := tokenizeExpr("price>=1.5e2&&`unit price`!= 'it\\'s' or año in (.5, 10)")
+	as.Nil(err, "there is an error in the function")
+
+	expected := []exprToken{
+		{tokenIdent, "price", 1, false},
+		{tokenOperator, ">=", 6, false},
+		{tokenFloat, "1.5e2", 8, false},
+		{tokenOperator, "&&", 13, false},
+		{tokenIdent, "unit price", 15, true},
+		{tokenOperator, "!=", 27, false},
+		{tokenString, "it's", 30, false},
+		{tokenIdent, "or", 38, false},
+		{tokenIdent, "año", 41, false},
+		{tokenIdent, "in", 46, false},
+		{tokenOperator, "(", 49, false},
+		{tokenFloat, ".5", 50, false},
+		{tokenOperator, ",", 52, false},
+		{tokenInt, "10", 54, false},
+		{tokenOperator, ")", 56, false},
+		{tokenEOF, "", 57, false},
+	}
+
+	as.Equal(expected, tokens, "the tokens are wrong")
+
+	errors := []struct {
+		expr string
+		err  string
+	}{
+		{"a = 1", "syntax error at position 3: invalid character '='"},
+		{"a == 'abc", "syntax error at position 6: the text isn't closed"},
+		{"a == 'a\\qb'", "syntax error at position 8: invalid escape \\q"},
+		{"a == 9223372036854775808", "syntax error at position 6: " +
+			"the number 9223372036854775808 is out of range"},
+	}
+
+	for _, test := range errors {
+		_, err := tokenizeExpr(test.expr)
+		as.EqualError(err, test.err, "the error of %s is wrong", test.expr)
+	}
+}
+
+func Test_DataFrame_parseExpr_errors(t *testing.T) {
+	as := assert.New(t)
+	df := makeQueryDataFrame(t)
+
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		// syntax errors.
+		{"", "syntax error at position 1: unexpected end of expression"},
+		{"price >", "syntax error at position 8: unexpected end of expression"},
+		{"price > 1 )", "syntax error at position 11: unexpected )"},
+		{"(price > 1", "syntax error at position 11: expected ), found end of expression"},
+		{"price < 1 < 2", "syntax error at position 11: unexpected <"},
+		{"country in 'ES'", `syntax error at position 12: expected (, found "ES"`},
+		{"country is 'ES'", `syntax error at position 12: expected null, found "ES"`},
+		{"price == null", "syntax error at position 10: unexpected null"},
+		{"country in ()", "syntax error at position 13: unexpected )"},
+		// columns and functions.
+		{"none > 1", "column none not found"},
+		{"`price` > 1 && signal > 1", "the column signal of type complex can't be used in an " +
+			"expression"},
+		{"round(price) > 1", "the function round doesn't exist"},
+		{"abs() > 1", "the function abs has 1 argument, not 0"},
+		{"abs(price, 2) > 1", "the function abs has 1 argument, not 2"},
+		{"len(price) > 1", "the function len is invalid with float"},
+		{"abs(country) == 'ES'", "the function abs is invalid with string"},
+		// types.
+		{"price > 'ES'", "the operator > is invalid between float and string"},
+		{"country in ('ES', 1)", "the operator in is invalid between string and int"},
+		{"price && stock > 1", "the operator && is invalid between float and bool"},
+		{"(price > 1) < true", "the operator < is invalid between bool and bool"},
+		{"!price", "the operator ! is invalid with float"},
+		{"-country == 'ES'", "the operator - is invalid with string"},
+		{"country * 2 > 1", "the operator * is invalid between string and int"},
+		{"price % 2 > 1", "the operator % is invalid between float and int"},
+		{"country + 1 == 'ES'", "the operator + is invalid between string and int"},
+	}
+
+	for _, test := range tests {
+		_, err := df.parseExpr(test.expr)
+		as.EqualError(err, test.err, "the error of %s is wrong", test.expr)
+	}
+
+	// the types of the expressions.
+	types := []struct {
+		expr  string
+		ctype columnType
+	}{
+		{"stock + 1", INT},
+		{"stock + units", INT},
+		{"units * units", UINT},
+		{"-units", INT},
+		{"stock / 2.0", FLOAT},
+		{"total", FLOAT},
+		{"abs(units)", UINT},
+		{"len(size)", INT},
+		{"lower(size) + 'x'", STRING},
+		{"not price > 1 or country is not null", exprBool},
+	}
+
+	for _, test := range types {
+		node, err := df.parseExpr(test.expr)
+		as.Nil(err, "there is an error parsing %s", test.expr)
+		as.Equal(test.ctype, node.etype(), "the type of %s is wrong", test.expr)
+	}
+}
@@ -0,0 +1,220 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// queryRow is the struct used in the expression tests.
+type queryRow struct {
+	Country  *string    `colName:"country"`
+	Price    float64    `colName:"price"`
+	Stock    *int       `colName:"stock"`
+	Units    uint       `colName:"units"`
+	Total    Decimal    `colName:"total"`
+	Size     string     `colName:"size"`
+	Signal   complex128 `colName:"signal"`
+	Discount *float64   `colName:"discount"`
+}
+
+// makeQueryDataFrame returns a DataFrame with a column of each type, and a category column.
+func makeQueryDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromStruct([]queryRow{
+		{strPtr("ES"), 12.5, intPtr(3), 2, mustDecimal(t, "25.00"), "M", 1, nil},
+		{strPtr("PT"), 8, intPtr(-1), 5, mustDecimal(t, "40.10"), "L", 2, floatPtr(0.5)},
+		{nil, 20, nil, 1, mustDecimal(t, "20.00"), "s", 3, floatPtr(1)},
+		{strPtr("ES"), 9.99, intPtr(10), 7, mustDecimal(t, "69.93"), "XL", 4, nil},
+		{strPtr("FR"), math.NaN(), intPtr(0), 0, mustDecimal(t, "0"), "M", 5, nil},
+	})
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	if df, err = df.AsCategory("size", CategoryOptions{}); err != nil {
+		assert.FailNow(t, "error creating the category column", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+func Test_DataFrame_Query_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeQueryDataFrame(t)
+
+	tests := []struct {
+		expr     string
+		expected []bool
+	}{
+		{"price > 10 && country == 'ES'", []bool{true, false, false, false, false}},
+		{"price > 10 and country == \"ES\"", []bool{true, false, false, false, false}},
+		{"country in ('ES', 'FR')", []bool{true, false, false, true, true}},
+		{"country not in ('ES', 'FR')", []bool{false, true, false, false, false}},
+		{"country is null", []bool{false, false, true, false, false}},
+		{"country IS NOT NULL", []bool{true, true, false, true, true}},
+		{"!(country == 'ES')", []bool{false, true, false, false, true}},
+		{"not stock > 0", []bool{false, true, false, false, true}},
+		{"stock > 0 || price >= 20", []bool{true, false, true, true, false}},
+		{"stock > 0 && price >= 20", []bool{false, false, false, false, false}},
+		{"stock * 2 + 1 == 7", []bool{true, false, false, false, false}},
+		{"stock > units", []bool{true, false, false, true, false}},
+		{"units - 3 < 0", []bool{true, false, true, false, true}},
+		{"-units < -4", []bool{false, true, false, true, false}},
+		{"stock / 3 == 1 && stock % 3 == 0", []bool{true, false, false, false, false}},
+		{"total >= 40.1", []bool{false, true, false, true, false}},
+		{"total == price * units", []bool{true, false, true, true, false}},
+		{"size in ('M', 'L')", []bool{true, true, false, false, true}},
+		{"upper(size) == 'S' || len(size) == 2", []bool{false, false, true, true, false}},
+		{"lower(country) + '-' + size == 'es-M'", []bool{true, false, false, false, false}},
+		{"abs(stock) == 1 && abs(-price) == 8", []bool{false, true, false, false, false}},
+		{"price != price", []bool{false, false, false, false, true}},
+		{"discount > 0.5 || discount is null", []bool{true, false, true, true, true}},
+		{"(price > 10) == (units > 1)", []bool{true, false, false, false, true}},
+		{"true", []bool{true, true, true, true, true}},
+	}
+
+	for _, test := range tests {
+		mask, err := df.QueryMask(test.expr)
+		as.Nil(err, "there is an error in the expression %s", test.expr)
+		as.Equal(test.expected, mask, "the mask of %s is wrong", test.expr)
+	}
+
+	result, err := df.Query("price > 10 && country == 'ES' || stock == -1")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(df.Headers(), result.Headers(), "the headers are wrong")
+	as.Equal([]string{"ES", "PT"}, columnStrings(result, "country"), "the values are wrong")
+	as.Equal([]string{"M", "L"}, columnStrings(result, "size"), "the values are wrong")
+
+	empty, err := df.Query("price > 100")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(0, empty.NumberRows(), "the DataFrame is empty")
+
+	// errors.
+	_, err = df.Query("price + 1")
+	as.EqualError(err, "the expression must be type bool, not float", "the error is wrong")
+
+	_, err = df.Query("units - units * units > 0")
+	as.EqualError(err, "error evaluating the row 0: the operation 2 - 4 overflows the type "+
+		"uint", "the error is wrong")
+
+	_, err = df.Query("stock / (stock - 3) > 0")
+	as.EqualError(err, "error evaluating the row 0: division by zero", "the error is wrong")
+}
+
+func Test_DataFrame_Query_Nulls(t *testing.T) {
+	as := assert.New(t)
+	df := makeQueryDataFrame(t)
+
+	// the rows 0 and 3 have null discount.
+	tests := []struct {
+		expr     string
+		expected []bool
+	}{
+		// false && null is false, so "not" is true.
+		{"!(discount > 0 && price < 0)", []bool{true, true, true, true, true}},
+		// true || null is true.
+		{"discount > 0 || price > 0", []bool{true, true, true, true, false}},
+		// null || false is null. The NaN floats aren't less than 0.
+		{"(discount > 0 || price < 0) is null", []bool{true, false, false, true, true}},
+		{"!(discount > 0 || price < 0)", []bool{false, false, false, false, false}},
+		// null in a list without the value is null.
+		{"!(price in (8, discount))", []bool{false, false, true, false, false}},
+		{"price in (8, discount)", []bool{false, true, false, false, false}},
+		{"(discount + 1) is null", []bool{true, false, false, true, true}},
+		{"abs(discount) is null", []bool{true, false, false, true, true}},
+	}
+
+	for _, test := range tests {
+		mask, err := df.QueryMask(test.expr)
+		as.Nil(err, "there is an error in the expression %s", test.expr)
+		as.Equal(test.expected, mask, "the mask of %s is wrong", test.expr)
+	}
+}
+
+func Test_DataFrame_Eval_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeQueryDataFrame(t)
+
+	result, err := df.Eval("value", "price * units - abs(stock)")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(FLOAT, result.Schema()[8].Type, "the column type is wrong")
+	values, _ := result.Column("value")
+	as.Equal("22", values[0].String(), "the value is wrong")
+	as.True(values[2].IsNull(), "the operations with null are null")
+	f, _ := values[3].Float64()
+	as.InDelta(59.93, f, 1e-9, "the value is wrong")
+
+	result, err = df.Eval("units2", "units + units")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(UINT, result.Schema()[8].Type, "the column type is wrong")
+	as.Equal([]string{"4", "10", "2", "14", "0"}, columnStrings(result, "units2"),
+		"the values are wrong")
+
+	result, err = df.Eval("label", "country + ':' + lower(size)")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(STRING, result.Schema()[8].Type, "the column type is wrong")
+	as.Equal([]string{"ES:m", "PT:l", "", "ES:xl", "FR:m"}, columnStrings(result, "label"),
+		"the values are wrong")
+
+	result, err = df.Eval("len", "len(`country`) - 3")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(INT, result.Schema()[8].Type, "the column type is wrong")
+	as.Equal([]string{"-1", "-1", "", "-1", "-1"}, columnStrings(result, "len"),
+		"the values are wrong")
+
+	// errors.
+	_, err = df.Eval("value", "price > 1")
+	as.EqualError(err, "the expression type bool can't be stored in a column",
+		"the error is wrong")
+
+	_, err = df.Eval("price", "price * 2")
+	as.EqualError(err, "the column price is duplicated", "the error is wrong")
+
+	_, err = df.Eval("value", "stock * 9223372036854775807")
+	as.EqualError(err, "error evaluating the row 0: the operation 3 * 9223372036854775807 "+
+		"overflows the type int", "the error is wrong")
+}
+
+func Test_intOperation_func(t *testing.T) {
+	as := assert.New(t)
+
+	tests := []struct {
+		op       string
+		a, b     int64
+		expected interface{}
+		err      bool
+	}{
+		{"+", math.MaxInt64, 1, nil, true},
+		{"+", math.MinInt64, -1, nil, true},
+		{"+", -5, 3, int64(-2), false},
+		{"-", math.MinInt64, 1, nil, true},
+		{"-", 0, math.MinInt64, nil, true},
+		{"-", -1, math.MaxInt64, int64(math.MinInt64), false},
+		{"*", math.MinInt64, -1, nil, true},
+		{"*", -1, math.MinInt64, nil, true},
+		{"*", 1 << 32, 1 << 31, nil, true},
+		{"*", -3, 4, int64(-12), false},
+		{"/", math.MinInt64, -1, nil, true},
+		{"/", -7, 2, int64(-3), false},
+		{"%", math.MinInt64, -1, int64(0), false},
+		{"%", -7, 2, int64(-1), false},
+		{"/", 1, 0, nil, true},
+		{"%", 1, 0, nil, true},
+	}
+
+	for _, test := range tests {
+		r, err := intOperation(test.op, test.a, test.b)
+		as.Equal(test.err, err != nil, "the error of %d %s %d is wrong", test.a, test.op, test.b)
+		if !test.err {
+			as.Equal(test.expected, r, "the result of %d %s %d is wrong", test.a, test.op, test.b)
+		}
+	}
+
+	_, err := uintOperation("-", 1, 2)
+	as.EqualError(err, "the operation 1 - 2 overflows the type uint", "the error is wrong")
+
+	_, err = uintOperation("*", math.MaxUint64, 2)
+	as.EqualError(err, "the operation 18446744073709551615 * 2 overflows the type uint",
+		"the error is wrong")
+}
@@ -0,0 +1,502 @@
+package dataframe
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// Tags of the values types in the row encoding.
+const (
+	encodedNull byte = iota
+	encodedInt
+	encodedUint
+	encodedFloat
+	encodedComplex
+	encodedString
+	encodedDecimal
+)
+
+// maxMergeRuns is the max number of runs merged at the same time, so the merge doesn't open
+// more files than the limit of the system.
+const maxMergeRuns = 64
+
+// errInvalidEncoding is the error returned when an encoded row is invalid.
+var errInvalidEncoding = errors.New("invalid encoded row")
+
+// encodeRow appends to buf the compact encoding of the row values of the columns. The row is
+// prefixed with its length. The custom types are encoded as their basic types.
+func encodeRow(buf []byte, row map[string]Value, columns []column) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	body := []byte{}
+
+	for _, col := range columns {
+		v := row[col.name]
+
+		switch raw := v.rawValue().(type) {
+		case int64:
+			body = append(body, encodedInt)
+			body = append(body, tmp[:binary.PutVarint(tmp[:], raw)]...)
+		case uint64:
+			body = append(body, encodedUint)
+			body = append(body, tmp[:binary.PutUvarint(tmp[:], raw)]...)
+		case float64:
+			body = append(body, encodedFloat)
+			body = binary.LittleEndian.AppendUint64(body, math.Float64bits(raw))
+		case complex128:
+			body = append(body, encodedComplex)
+			body = binary.LittleEndian.AppendUint64(body, math.Float64bits(real(raw)))
+			body = binary.LittleEndian.AppendUint64(body, math.Float64bits(imag(raw)))
+		case string:
+			body = append(body, encodedString)
+			body = append(body, tmp[:binary.PutUvarint(tmp[:], uint64(len(raw)))]...)
+			body = append(body, raw...)
+		case Decimal:
+			body = append(body, encodedDecimal)
+			body = append(body, tmp[:binary.PutVarint(tmp[:], raw.unscaled)]...)
+			body = append(body, byte(raw.scale))
+		default:
+			body = append(body, encodedNull)
+		}
+	}
+
+	buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(len(body)))]...)
+	return append(buf, body...)
+}
+
+// decodeRow reads from r a row encoded with encodeRow. Returns io.EOF if there aren't more
+// rows.
+func decodeRow(r *bufio.Reader, columns []column) (map[string]Value, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, errInvalidEncoding
+	}
+
+	row := make(map[string]Value, len(columns))
+	for _, col := range columns {
+		if len(body) == 0 {
+			return nil, errInvalidEncoding
+		}
+
+		tag := body[0]
+		body = body[1:]
+		n := 0
+
+		switch tag {
+		case encodedNull:
+			row[col.name] = Value{}
+			continue
+		case encodedInt:
+			var i int64
+			i, n = binary.Varint(body)
+			row[col.name] = newIntValue(i)
+		case encodedUint:
+			var u uint64
+			u, n = binary.Uvarint(body)
+			row[col.name] = newUintValue(u)
+		case encodedFloat:
+			if len(body) < 8 {
+				return nil, errInvalidEncoding
+			}
+
+			row[col.name] = newFloatValue(math.Float64frombits(binary.LittleEndian.Uint64(body)))
+			n = 8
+		case encodedComplex:
+			if len(body) < 16 {
+				return nil, errInvalidEncoding
+			}
+
+			re := math.Float64frombits(binary.LittleEndian.Uint64(body))
+			im := math.Float64frombits(binary.LittleEndian.Uint64(body[8:]))
+			row[col.name] = newComplexValue(complex(re, im))
+			n = 16
+		case encodedString:
+			var l uint64
+			l, n = binary.Uvarint(body)
+			if n <= 0 || uint64(len(body)-n) < l {
+				return nil, errInvalidEncoding
+			}
+
+			row[col.name] = newStringValue(string(body[n : n+int(l)]))
+			n += int(l)
+		case encodedDecimal:
+			var i int64
+			i, n = binary.Varint(body)
+			if n <= 0 || len(body) == n {
+				return nil, errInvalidEncoding
+			}
+
+			row[col.name] = newDecimalValue(Decimal{i, int(body[n])})
+			n++
+		default:
+			return nil, errInvalidEncoding
+		}
+
+		if n <= 0 {
+			return nil, errInvalidEncoding
+		}
+
+		body = body[n:]
+	}
+
+	return row, nil
+}
+
+// externalSorter orders rows larger than the memory. The rows are read in runs that fit in
+// the memory budget, each run is ordered and spilled to a temporal file and, finally, the
+// runs are merged.
+type externalSorter struct {
+	// Columns of the rows.
+	columns []column
+	// Order of the rows.
+	order []internalOrderColumn
+	// Comparer functions of the order columns.
+	comparers []func(a, b Value) (Comparers, error)
+	// Approximate memory, in bytes, used by each run.
+	memory int64
+	// Directory of the temporal files.
+	tempDir string
+	// Max number of runs merged at the same time.
+	fanIn int
+}
+
+// newExternalSorter creates a new externalSorter.
+func newExternalSorter(
+	columns []column, order []internalOrderColumn, memory int64, tempDir string,
+) *externalSorter {
+	s := externalSorter{
+		columns: columns, order: order, memory: memory, tempDir: tempDir, fanIn: maxMergeRuns,
+	}
+
+	for i := range order {
+		s.comparers = append(s.comparers, order[i].valueComparer())
+	}
+
+	return &s
+}
+
+// less returns true if the a row is less than the b row, using the sorter order.
+func (s *externalSorter) less(a, b map[string]Value) bool {
+	for i, f := range s.comparers {
+		ocol := &s.order[i]
+		comp, _ := ocol.compare(f, a[ocol.column.name], b[ocol.column.name])
+
+		if comp != EQUAL {
+			return comp == LESS
+		}
+	}
+
+	return false
+}
+
+// spill orders the rows and writes them in a new temporal file. Returns the filename.
+func (s *externalSorter) spill(rows []map[string]Value) (string, error) {
+	sort.SliceStable(rows, func(i, j int) bool { return s.less(rows[i], rows[j]) })
+
+	f, err := os.CreateTemp(s.tempDir, "dataframe-run-*")
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	writer := bufio.NewWriter(f)
+	buf := []byte{}
+	for _, row := range rows {
+		buf = encodeRow(buf[:0], row, s.columns)
+		if _, err := writer.Write(buf); err != nil {
+			os.Remove(f.Name())
+			return "", err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// runs reads the n rows using the get function and spills them to ordered runs. Returns
+// the filenames of the runs.
+func (s *externalSorter) runs(n int, get func(i int) (map[string]Value, error)) ([]string, error) {
+	files := []string{}
+	rows := []map[string]Value{}
+	size := int64(0)
+	buf := []byte{}
+
+	removeFiles := func() {
+		for _, name := range files {
+			os.Remove(name)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		row, err := get(i)
+		if err != nil {
+			removeFiles()
+			return nil, err
+		}
+
+		// the memory used by the row is estimated using the length of its encoding.
+		buf = encodeRow(buf[:0], row, s.columns)
+		size += int64(len(buf))*4 + 64
+		rows = append(rows, row)
+
+		if size >= s.memory || i == n-1 {
+			name, err := s.spill(rows)
+			if err != nil {
+				removeFiles()
+				return nil, err
+			}
+
+			files = append(files, name)
+			rows = rows[:0]
+			size = 0
+		}
+	}
+
+	return files, nil
+}
+
+// runReader reads the rows of a run in the merge.
+type runReader struct {
+	index  int
+	file   *os.File
+	reader *bufio.Reader
+	row    map[string]Value
+}
+
+// runHeap is the heap with the next row of each run, used in the k-way merge.
+type runHeap struct {
+	sorter  *externalSorter
+	readers []*runReader
+}
+
+func (h *runHeap) Len() int { return len(h.readers) }
+
+func (h *runHeap) Less(i, j int) bool {
+	a, b := h.readers[i], h.readers[j]
+	if h.sorter.less(a.row, b.row) {
+		return true
+	} else if h.sorter.less(b.row, a.row) {
+		return false
+	}
+
+	// The equal rows keep the order of the runs, so the merge is stable.
+	return a.index < b.index
+}
+
+func (h *runHeap) Swap(i, j int) { h.readers[i], h.readers[j] = h.readers[j], h.readers[i] }
+
+func (h *runHeap) Push(x interface{}) { h.readers = append(h.readers, x.(*runReader)) }
+
+func (h *runHeap) Pop() interface{} {
+	last := h.readers[len(h.readers)-1]
+	h.readers = h.readers[:len(h.readers)-1]
+	return last
+}
+
+// merge merges the ordered runs in the w writer, using the row encoding. Returns the offset
+// of each row in the output. The last offset is the end of the last row.
+// Each run file is closed when all its rows are read.
+func (s *externalSorter) merge(runs []string, w io.Writer) ([]int64, error) {
+	h := runHeap{sorter: s}
+
+	defer func() {
+		for _, r := range h.readers {
+			r.file.Close()
+		}
+	}()
+
+	for i, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+
+		r := runReader{index: i, file: f, reader: bufio.NewReader(f)}
+		r.row, err = decodeRow(r.reader, s.columns)
+		if err == nil {
+			h.readers = append(h.readers, &r)
+			continue
+		}
+
+		f.Close()
+		if err != io.EOF {
+			return nil, err
+		}
+	}
+
+	heap.Init(&h)
+	writer := bufio.NewWriter(w)
+	offsets := []int64{0}
+	buf := []byte{}
+
+	for h.Len() > 0 {
+		r := h.readers[0]
+		buf = encodeRow(buf[:0], r.row, s.columns)
+		if _, err := writer.Write(buf); err != nil {
+			return nil, err
+		}
+
+		offsets = append(offsets, offsets[len(offsets)-1]+int64(len(buf)))
+
+		var err error
+		if r.row, err = decodeRow(r.reader, s.columns); err == nil {
+			heap.Fix(&h, 0)
+		} else if err == io.EOF {
+			heap.Pop(&h)
+			r.file.Close()
+		} else {
+			return nil, err
+		}
+	}
+
+	return offsets, writer.Flush()
+}
+
+// reduceRuns merges the runs in groups of fanIn runs, in several passes, until there are
+// fanIn runs or less, so the final merge doesn't open too many files. The groups contain
+// consecutive runs, so the merge keeps stable. The merged runs are removed. Returns the new
+// runs. If there is an error, all runs are removed.
+func (s *externalSorter) reduceRuns(runs []string) ([]string, error) {
+	for len(runs) > s.fanIn {
+		merged := []string{}
+
+		for len(runs) > 0 {
+			size := s.fanIn
+			if len(runs) < size {
+				size = len(runs)
+			}
+
+			group := runs[:size]
+			name, err := s.mergeRuns(group)
+			if err != nil {
+				for _, name := range append(merged, runs...) {
+					os.Remove(name)
+				}
+
+				return nil, err
+			}
+
+			for _, name := range group {
+				os.Remove(name)
+			}
+
+			merged = append(merged, name)
+			runs = runs[len(group):]
+		}
+
+		runs = merged
+	}
+
+	return runs, nil
+}
+
+// mergeRuns merges the runs in a new run. Returns the filename of the new run.
+func (s *externalSorter) mergeRuns(runs []string) (string, error) {
+	f, err := os.CreateTemp(s.tempDir, "dataframe-run-*")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = s.merge(runs, f); err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// sort orders the n rows, read with the get function, and writes them in a new temporal
+// file. Returns the rows file.
+func (s *externalSorter) sort(n int, get func(i int) (map[string]Value, error)) (*rowsFile, error) {
+	runs, err := s.runs(n, get)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		for _, name := range runs {
+			os.Remove(name)
+		}
+	}()
+
+	if runs, err = s.reduceRuns(runs); err != nil {
+		return nil, fmt.Errorf("error merging the ordered rows: %s", err.Error())
+	}
+
+	f, err := os.CreateTemp(s.tempDir, "dataframe-sorted-*")
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	offsets, err := s.merge(runs, f)
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("error merging the ordered rows: %s", err.Error())
+	}
+
+	return &rowsFile{filename: f.Name(), columns: s.columns, offsets: offsets}, nil
+}
+
+// rowsFile is a file with rows encoded with encodeRow.
+type rowsFile struct {
+	// filename of the file.
+	filename string
+	// Columns of the rows.
+	columns []column
+	// Offset of each row in the file. The last offset is the end of the last row.
+	offsets []int64
+}
+
+// read reads the rows between min and max of the file.
+func (rf *rowsFile) read(min, max int) ([]map[string]Value, error) {
+	f, err := os.Open(rf.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	section := io.NewSectionReader(f, rf.offsets[min], rf.offsets[max]-rf.offsets[min])
+	reader := bufio.NewReader(section)
+	rows := make([]map[string]Value, 0, max-min)
+
+	for i := min; i < max; i++ {
+		row, err := decodeRow(reader, rf.columns)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the row %d: %s", i, err.Error())
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// remove removes the file.
+func (rf *rowsFile) remove() error {
+	return os.Remove(rf.filename)
+}
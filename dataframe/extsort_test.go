@@ -0,0 +1,137 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_encodeRow_func(t *testing.T) {
+	as := assert.New(t)
+	columns := []column{
+		{"i", INT, 0, true, ColumnMeta{}},
+		{"u", UINT, 1, true, ColumnMeta{}},
+		{"f", FLOAT, 2, true, ColumnMeta{}},
+		{"c", COMPLEX, 3, true, ColumnMeta{}},
+		{"s", STRING, 4, true, ColumnMeta{}},
+	}
+
+	rows := []map[string]Value{
+		{
+			"i": newIntValue(math.MinInt64), "u": newUintValue(math.MaxUint64),
+			"f": newFloatValue(-1.5), "c": newComplexValue(complex(1, -2)),
+			"s": newStringValue("a\nñ"),
+		},
+		{"i": Value{}, "u": Value{}, "f": Value{}, "c": Value{}, "s": newStringValue("")},
+	}
+
+	buf := []byte{}
+	for _, row := range rows {
+		buf = encodeRow(buf, row, columns)
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(buf))
+	for i, expected := range rows {
+		row, err := decodeRow(reader, columns)
+		as.Nil(err, "there is an error in the function")
+		as.Equal(expected, row, "the row %d is wrong", i)
+	}
+
+	_, err := decodeRow(reader, columns)
+	as.Equal(io.EOF, err, "there aren't more rows")
+
+	_, err = decodeRow(bufio.NewReader(bytes.NewReader(buf[:len(buf)-1])), columns)
+	as.Nil(err, "the first row is valid")
+	reader = bufio.NewReader(bytes.NewReader([]byte{2, 9, 0}))
+	_, err = decodeRow(reader, columns)
+	as.Equal(errInvalidEncoding, err, "the error doesn't match")
+}
+
+func Test_externalSorter_sort(t *testing.T) {
+	as := assert.New(t)
+	columns := []column{
+		{"group", INT, 0, true, ColumnMeta{}},
+		{"name", STRING, 1, true, ColumnMeta{}},
+	}
+
+	order := []internalOrderColumn{
+		{&columns[0], DESC, OrderOptions{Nulls: NULLS_FIRST}},
+	}
+
+	rows := []map[string]Value{}
+	for i := 0; i < 500; i++ {
+		group := newIntValue(int64(i % 7))
+		if i%50 == 0 {
+			group = Value{}
+		}
+
+		rows = append(rows, map[string]Value{
+			"group": group, "name": newStringValue(fmt.Sprintf("%03d", i)),
+		})
+	}
+
+	get := func(i int) (map[string]Value, error) { return rows[i], nil }
+	dir := t.TempDir()
+	sorter := newExternalSorter(columns, order, 1024, dir)
+
+	runs, err := sorter.runs(len(rows), get)
+	as.Nil(err, "there is an error in the function")
+	as.True(len(runs) > 1, "the rows must be spilled in several runs")
+	for _, name := range runs {
+		os.Remove(name)
+	}
+
+	sorted, err := sorter.sort(len(rows), get)
+	if err != nil {
+		as.FailNow("error sorting the rows", "error: %s", err.Error())
+	}
+
+	result, err := sorted.read(0, len(rows))
+	as.Nil(err, "there is an error in the function")
+	as.Equal(len(rows), len(result), "the number of rows is wrong")
+
+	// the null values are the first and the equal rows keep their order.
+	for i := 1; i < len(result); i++ {
+		prev, curr := result[i-1], result[i]
+		comp, _ := order[0].compare(sorter.comparers[0], prev["group"], curr["group"])
+		as.NotEqual(GREAT, comp, "the row %d isn't ordered", i)
+
+		if comp == EQUAL {
+			prevValue, currValue := prev["name"], curr["name"]
+			prevName, _ := prevValue.Str()
+			currName, _ := currValue.Str()
+			as.True(prevName < currName, "the row %d isn't stable", i)
+		}
+	}
+
+	first := result[0]["group"]
+	as.True(first.IsNull(), "the null values must be the first")
+	last := result[len(result)-1]["group"]
+	group, _ := last.Int64()
+	as.Equal(int64(0), group, "the last group is wrong")
+	as.Nil(sorted.remove(), "there is an error in the function")
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	as.Equal(0, len(files), "the temporal files must be removed")
+
+	// the runs are merged in several passes when there are more runs than the fan-in.
+	sorter.fanIn = 3
+	sorted, err = sorter.sort(len(rows), get)
+	if err != nil {
+		as.FailNow("error sorting the rows", "error: %s", err.Error())
+	}
+
+	merged, err := sorted.read(0, len(rows))
+	as.Nil(err, "there is an error in the function")
+	as.Equal(result, merged, "the rows merged in several passes are wrong")
+	as.Nil(sorted.remove(), "there is an error in the function")
+
+	files, _ = filepath.Glob(filepath.Join(dir, "*"))
+	as.Equal(0, len(files), "the temporal files must be removed")
+}
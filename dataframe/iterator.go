@@ -33,6 +33,8 @@ type Iterator struct {
 	index int
 	// number of rows in DataFrame
 	numberRows int
+	// flag indicating the DataFrame read lock is held while the iterator is used.
+	locked bool
 }
 
 // newIterator create a new iterator.
@@ -41,7 +43,7 @@ func newIterator(df *DataFrame, min, max int) (*Iterator, error) {
 	if err := df.checkRange(min, max); err != nil {
 		return nil, err
 	}
-	return &Iterator{df, min, min, max, 0, df.NumberRows()}, nil
+	return &Iterator{df, min, min, max, 0, df.NumberRows(), false}, nil
 }
 
 // Next returns the current row of the iterator and advance one position.
@@ -60,10 +62,11 @@ func (it *Iterator) Next() (Row, bool) {
 // Current returns the current row.
 func (it *Iterator) Current() Row {
 	if it.pos >= it.max || it.pos >= it.numberRows {
-		return Row{nil, 0}
+		return Row{nil, 0, false}
 	}
 
 	row, _ := newRow(it.df, it.pos)
+	row.locked = it.locked
 	return row
 }
 
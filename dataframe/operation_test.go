@@ -2,6 +2,7 @@ package dataframe
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"testing"
 )
 
@@ -207,16 +208,9 @@ func Test_DataFrame_MaxRange_func(t *testing.T) {
 	}
 
 	// error iterator
-	expectedR = map[string]interface{}{
-		"col A": int64(0),
-		"col B": uint64(0),
-		"col C": float64(0),
-		"col D": 0 + 0i,
-	}
-
-	for colName, expected := range expectedR {
+	for colName := range expectedR {
 		value, err = df.MaxRange(colName, -1, 3)
-		as.Equal(value, expected, "must be 0 because there is an error")
+		as.Nil(value, "there is an error, value must be nil")
 		as.Equal(
 			err.Error(),
 			"index must be non-negative number",
@@ -326,16 +320,9 @@ func Test_DataFrame_MinRange_func(t *testing.T) {
 	}
 
 	// error iterator
-	expectedR = map[string]interface{}{
-		"col A": int64(0),
-		"col B": uint64(0),
-		"col C": float64(0),
-		"col D": 0 + 0i,
-	}
-
-	for colName, expected := range expectedR {
+	for colName := range expectedR {
 		value, err = df.MinRange(colName, -1, 3)
-		as.Equal(value, expected, "must be 0 because there is an error")
+		as.Nil(value, "there is an error, value must be nil")
 		as.Equal(
 			err.Error(),
 			"index must be non-negative number",
@@ -404,3 +391,160 @@ func Test_DataFrame_Min_func(t *testing.T) {
 		"the error message does not match",
 	)
 }
+
+func Test_DataFrame_MinMax_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"f": []float64{-3.5, math.NaN(), -1.25, -7},
+		"s": []string{"pear", "apple", "zucchini", "fig"},
+		"i": []*int{nil, nil, nil, nil},
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	// all negative floats and NaN values, that are ignored.
+	min, max, err := df.MinMax("f")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(-7.0, min, "the min is wrong")
+	as.Equal(-1.25, max, "the max is wrong")
+
+	value, _ := df.Max("f")
+	as.Equal(-1.25, value, "the max is wrong")
+
+	min, max, err = df.MinMax("s")
+	as.Nil(err, "there is an error in the function")
+	as.Equal("apple", min, "the min is wrong")
+	as.Equal("zucchini", max, "the max is wrong")
+
+	min, max, _ = df.MinMaxRange("s", 2, 4)
+	as.Equal("fig", min, "the min is wrong")
+	as.Equal("zucchini", max, "the max is wrong")
+
+	// there aren't values.
+	_, _, err = df.MinMax("i")
+	as.Equal("the column i hasn't values", err.Error(), "the error message doesn't match")
+	_, err = df.MinRange("f", 2, 2)
+	as.Equal("the column f hasn't values", err.Error(), "the error message doesn't match")
+	_, err = df.Max("i")
+	as.Equal("the column i hasn't values", err.Error(), "the error message doesn't match")
+
+	_, _, err = df.MinMax("none")
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+	_, _, err = df.MinMaxRange("f", 3, 1)
+	as.Equal("max index < min index", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_OperationParallel_func(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	data := []mockData{}
+	for i := 1; i <= 1000; i++ {
+		data = append(data, mockData{i, -i})
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, 2000} {
+		sum := OperatrionSumInt{OperationBaseInt{OperationBase{"a"}, 0}}
+		err := df.OperationParallel(&sum, workers)
+		as.Nil(err, "there is an error in the operation")
+		as.Equalf(int64(500500), sum.Total, "the sum is wrong with %d workers", workers)
+
+		max := OperationIntMinOrMax{
+			OperationBaseInt{OperationBase{"b"}, math.MinInt64}, GREAT}
+		err = df.OperationParallel(&max, workers)
+		as.Nil(err, "there is an error in the operation")
+		as.Equalf(int64(-1), max.Total, "the max is wrong with %d workers", workers)
+
+		mean := OperationMean{OperationBase{"a"}, 0, 0}
+		err = df.OperationParallel(&mean, workers)
+		as.Nil(err, "there is an error in the operation")
+		result, _ := mean.Result()
+		as.Equalf(500.5, result, "the mean is wrong with %d workers", workers)
+	}
+
+	// range
+	sum := OperatrionSumInt{OperationBaseInt{OperationBase{"a"}, 0}}
+	err := df.OperationParallelRange(&sum, 4, 10, 20)
+	as.Nil(err, "there is an error in the operation")
+	as.Equal(int64(155), sum.Total, "the sum is wrong")
+
+	// errors
+	err = df.OperationParallelRange(&sum, 4, -1, 20)
+	as.Equal("index must be non-negative number", err.Error(), "invalid error message")
+
+	other := OperatrionSumUint{OperationBaseUint{OperationBase{"a"}, 0}}
+	as.Equal("the operations can't be merged", sum.Merge(&other).Error(),
+		"invalid error message")
+}
+
+func Test_DataFrame_OperationParallel_concurrent_order(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	data := []mockData{}
+	for i := 1; i <= 1000; i++ {
+		data = append(data, mockData{i, -i})
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 20; i++ {
+			df.Order(OrderColumn{"a", ASC})
+			df.Order(OrderColumn{"b", ASC})
+		}
+		done <- true
+	}()
+
+	// all the ranges read the same order, so the sum is of the first or the last 500 values.
+	for i := 0; i < 20; i++ {
+		sum := OperatrionSumInt{OperationBaseInt{OperationBase{"a"}, 0}}
+		err := df.OperationParallelRange(&sum, 8, 0, 500)
+		as.Nil(err, "there is an error in the operation")
+		as.Contains([]int64{125250, 375250}, sum.Total, "the sum is wrong")
+	}
+
+	<-done
+}
+
+func Test_DataFrame_MeanRange_func(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = getDataFrameToColumns(t); df == nil {
+		return
+	}
+
+	value, err := df.MeanRange("col A", 1, 3)
+	as.Nil(err, "there is an error in the operation")
+	as.Equal(-2.5, value, "the value is different")
+
+	value, err = df.MeanRange("col B", 0, 9)
+	as.Nil(err, "there is an error in the operation")
+	as.Equal(float64(5), value, "the value is different")
+
+	value, err = df.Mean("col C")
+	as.Nil(err, "there is an error in the operation")
+	as.Equal(float32(5.555), float32(value), "the value is different")
+
+	// errors
+	_, err = df.MeanRange("col A", 1, 1)
+	as.Equal("there aren't values to calculate the mean", err.Error(), "invalid error message")
+
+	_, err = df.Mean("col D")
+	as.Equal("Mean operation is invalid in column type complex", err.Error(),
+		"invalid error message")
+
+	_, err = df.Mean("not-exists")
+	as.Equal("column not-exists not found", err.Error(), "invalid error message")
+}
@@ -1,8 +1,11 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
-	"math"
+	"math/bits"
+	"runtime"
+	"sync"
 )
 
 // OperationBase is the base struct for all operations.
@@ -35,32 +38,94 @@ type OperationBaseComplex struct {
 }
 
 // OperationSumInt is a struct used to sum all values of a DataFrame column type int.
+//
+// Deprecated: use NewSumAggregator.
 type OperatrionSumInt struct {
 	OperationBaseInt
 }
 
 //F sum the value of the Cell Colname, fetched from r, with the total value: Total.
+// Returns an error if the sum overflows.
 func (o *OperatrionSumInt)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
 	result, _ := v.Int64()
-	o.Total += result
+
+	total, overflow := addInt64(o.Total, result)
+	if overflow {
+		return errIntOverflow
+	}
+
+	o.Total = total
+	return nil
+}
+
+// New returns a new sum operation of the same column, with the total value to 0.
+func (o *OperatrionSumInt) New() MergeableOperation {
+	return &OperatrionSumInt{OperationBaseInt{o.OperationBase, 0}}
+}
+
+// Merge sums the total value of other operation with the total value: Total.
+func (o *OperatrionSumInt) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperatrionSumInt)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	total, overflow := addInt64(o.Total, op.Total)
+	if overflow {
+		return errIntOverflow
+	}
+
+	o.Total = total
 	return nil
 }
 
 // OperationSumUint is a struct used to sum all values of a DataFrame column type uint.
+//
+// Deprecated: use NewSumAggregator.
 type OperatrionSumUint struct {
 	OperationBaseUint
 }
 
 //F sum the value of the Cell Colname, fetched from r, with the total value: Total.
+// Returns an error if the sum overflows.
 func (o *OperatrionSumUint)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
 	result, _ := v.Uint64()
-	o.Total += result
+
+	total, carry := bits.Add64(o.Total, result, 0)
+	if carry != 0 {
+		return errIntOverflow
+	}
+
+	o.Total = total
+	return nil
+}
+
+// New returns a new sum operation of the same column, with the total value to 0.
+func (o *OperatrionSumUint) New() MergeableOperation {
+	return &OperatrionSumUint{OperationBaseUint{o.OperationBase, 0}}
+}
+
+// Merge sums the total value of other operation with the total value: Total.
+func (o *OperatrionSumUint) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperatrionSumUint)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	total, carry := bits.Add64(o.Total, op.Total, 0)
+	if carry != 0 {
+		return errIntOverflow
+	}
+
+	o.Total = total
 	return nil
 }
 
 // OperationSumFloat is a struct used to sum all values of a DataFrame column type float.
+//
+// Deprecated: use NewSumAggregator.
 type OperatrionSumFloat struct {
 	OperationBaseFloat
 }
@@ -73,7 +138,25 @@ func (o *OperatrionSumFloat)F(r *Row) error {
 	return nil
 }
 
+// New returns a new sum operation of the same column, with the total value to 0.
+func (o *OperatrionSumFloat) New() MergeableOperation {
+	return &OperatrionSumFloat{OperationBaseFloat{o.OperationBase, 0}}
+}
+
+// Merge sums the total value of other operation with the total value: Total.
+func (o *OperatrionSumFloat) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperatrionSumFloat)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	o.Total += op.Total
+	return nil
+}
+
 // OperatrionSumComplex is a struct used to sum all values of a DataFrame column type complex.
+//
+// Deprecated: use NewSumAggregator.
 type OperatrionSumComplex struct {
 	OperationBaseComplex
 }
@@ -86,9 +169,34 @@ func (o *OperationBaseComplex)F(r *Row) error {
 	return nil
 }
 
+// New returns a new sum operation of the same column, with the total value to 0.
+func (o *OperatrionSumComplex) New() MergeableOperation {
+	return &OperatrionSumComplex{OperationBaseComplex{o.OperationBase, 0}}
+}
+
+// Merge sums the total value of other operation with the total value: Total.
+func (o *OperatrionSumComplex) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperatrionSumComplex)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	o.Total += op.Total
+	return nil
+}
+
 // Operation Execute the func operation using the DataFrame rows between min and max
 func (df *DataFrame) OperationRange(op Operation, min, max int) (error) {
-	iterator, err := df.IteratorRange(min, max)
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	return df.lockedOperationRange(op, min, max)
+}
+
+// lockedOperationRange executes the op operation using the DataFrame rows between min and
+// max, while the caller holds the read lock of the DataFrame.
+func (df *DataFrame) lockedOperationRange(op Operation, min, max int) error {
+	iterator, err := df.lockedIteratorRange(min, max)
 	if err != nil {
 		return err
 	}
@@ -107,6 +215,79 @@ func (df *DataFrame) Operation(op Operation) error {
 	return df.OperationRange(op, 0, df.NumberRows())
 }
 
+// OperationParallelRange executes the op operation using the DataFrame rows between min and
+// max. The rows are split in as many ranges as workers, and each range is executed in a
+// goroutine by a new operation, made with op.New. At last, the partial results are merged in
+// op, in the order of the ranges. If workers is less than 1, it uses GOMAXPROCS workers.
+// The DataFrame is locked during the whole operation, so all ranges read the same order of
+// the rows.
+func (df *DataFrame) OperationParallelRange(
+	op MergeableOperation, workers int, min, max int,
+) error {
+	if err := df.checkRange(min, max); err != nil {
+		return err
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	if max > df.handler.Len() {
+		max = df.handler.Len()
+	}
+
+	if size := max - min; size < workers {
+		workers = size
+	}
+
+	if workers <= 1 {
+		return df.lockedOperationRange(op, min, max)
+	}
+
+	parts := make([]MergeableOperation, workers)
+	errs := make([]error, workers)
+	chunk := (max - min) / workers
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		pmin := min + i*chunk
+		pmax := pmin + chunk
+		if i == workers-1 {
+			pmax = max
+		}
+
+		parts[i] = op.New()
+		wg.Add(1)
+		go func(i, pmin, pmax int) {
+			defer wg.Done()
+			errs[i] = df.lockedOperationRange(parts[i], pmin, pmax)
+		}(i, pmin, pmax)
+	}
+
+	wg.Wait()
+
+	for i, part := range parts {
+		if errs[i] != nil {
+			return errs[i]
+		}
+
+		if err := op.Merge(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OperationParallel executes the op operation in all DataFrame rows, using several
+// goroutines. See OperationParallelRange.
+func (df *DataFrame) OperationParallel(op MergeableOperation, workers int) error {
+	return df.OperationParallelRange(op, workers, 0, df.NumberRows())
+}
+
 // Sum sum values of the column colName, between rows min and max.
 // Only it can use the function with columns that has a valid type.
 // The value returned will depend of the colum type:
@@ -114,34 +295,14 @@ func (df *DataFrame) Operation(op Operation) error {
 //	- uint	  uint64
 //	- float	  float32
 //	- complex complex128
+// Returns an error if the sum of an integer column overflows. See SumRangeWithOptions.
 func (df *DataFrame)SumRange(colName string, min, max int) (interface{}, error) {
-	column, exists := df.getColumnByName(colName)
-
-	if !exists {
-		return nil, fmt.Errorf("column %s not found", colName)
+	value, err := df.AggregateRange(NewSumAggregator(colName), min, max)
+	if err != nil {
+		return nil, err
 	}
 
-	switch column.ctype {
-	case INT:
-		op := OperatrionSumInt{OperationBaseInt{OperationBase{colName}, 0}}
-		err := df.OperationRange(&op, min, max)
-		return op.Total, err
-	case UINT:
-		op := OperatrionSumUint{OperationBaseUint{OperationBase{colName}, 0}}
-		err := df.OperationRange(&op, min, max)
-		return op.Total, err
-	case FLOAT:
-		op := OperatrionSumFloat{OperationBaseFloat{OperationBase{colName}, 0}}
-		err := df.OperationRange(&op, min, max)
-		return op.Total, err
-	case COMPLEX:
-		op := OperatrionSumComplex{OperationBaseComplex{OperationBase{colName}, 0}}
-		err := df.OperationRange(&op, min, max)
-		return op.Total, err
-
-	default:
-		return nil, fmt.Errorf("Sum operation is invalid in column type %s", column.ctype)
-	}
+	return value.rawValue(), nil
 }
 
 
@@ -157,6 +318,8 @@ func (df *DataFrame)Sum(colname string) (interface{}, error) {
 
 // OperationIntMinOrMax is a struct that calculates the min or
 // the max of a Dataframe column of type int
+//
+// Deprecated: use NewMinAggregator or NewMaxAggregator.
 type OperationIntMinOrMax struct {
 	OperationBaseInt
 	cvalue Comparers
@@ -165,6 +328,10 @@ type OperationIntMinOrMax struct {
 // F checks if column value in the row is more great or less than the value of the struct.
 func (o *OperationIntMinOrMax)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
+	if v.IsNull() {
+		return nil
+	}
+
 	itype, _ := v.IntType()
 
 	if itype.Compare(o.Total) == o.cvalue {
@@ -174,8 +341,31 @@ func (o *OperationIntMinOrMax)F(r *Row) error {
 	return nil
 }
 
+// New returns a new operation of the same column, using the current Total value as
+// initial value.
+func (o *OperationIntMinOrMax) New() MergeableOperation {
+	return &OperationIntMinOrMax{OperationBaseInt{o.OperationBase, o.Total}, o.cvalue}
+}
+
+// Merge checks if the Total value of other operation is more great or less than the
+// value of the struct.
+func (o *OperationIntMinOrMax) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperationIntMinOrMax)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	if (simpleIntType{op.Total}).Compare(o.Total) == o.cvalue {
+		o.Total = op.Total
+	}
+
+	return nil
+}
+
 // OperationIntMinOrMax is a struct that calculates the min or
 // the max of a Dataframe column of type uint
+//
+// Deprecated: use NewMinAggregator or NewMaxAggregator.
 type OperationUintMinOrMax struct {
 	OperationBaseUint
 	cvalue Comparers
@@ -184,6 +374,10 @@ type OperationUintMinOrMax struct {
 // F checks if column value in the row is more great or less than the value of the struct.
 func (o *OperationUintMinOrMax)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
+	if v.IsNull() {
+		return nil
+	}
+
 	utype, _ := v.UintType()
 
 	if utype.Compare(o.Total) == o.cvalue {
@@ -193,8 +387,31 @@ func (o *OperationUintMinOrMax)F(r *Row) error {
 	return nil
 }
 
+// New returns a new operation of the same column, using the current Total value as
+// initial value.
+func (o *OperationUintMinOrMax) New() MergeableOperation {
+	return &OperationUintMinOrMax{OperationBaseUint{o.OperationBase, o.Total}, o.cvalue}
+}
+
+// Merge checks if the Total value of other operation is more great or less than the
+// value of the struct.
+func (o *OperationUintMinOrMax) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperationUintMinOrMax)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	if (simpleUintType{op.Total}).Compare(o.Total) == o.cvalue {
+		o.Total = op.Total
+	}
+
+	return nil
+}
+
 // OperationIntMinOrMax is a struct that calculates the min or
 // the max of a Dataframe column of type float
+//
+// Deprecated: use NewMinAggregator or NewMaxAggregator.
 type OperationFloatMinOrMax struct {
 	OperationBaseFloat
 	cvalue Comparers
@@ -203,6 +420,10 @@ type OperationFloatMinOrMax struct {
 // F checks if column value in the row is more great or less than the value of the struct.
 func (o *OperationFloatMinOrMax)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
+	if v.IsNull() {
+		return nil
+	}
+
 	ftype, _ := v.FloatType()
 
 	if ftype.Compare(o.Total) == o.cvalue {
@@ -212,8 +433,31 @@ func (o *OperationFloatMinOrMax)F(r *Row) error {
 	return nil
 }
 
+// New returns a new operation of the same column, using the current Total value as
+// initial value.
+func (o *OperationFloatMinOrMax) New() MergeableOperation {
+	return &OperationFloatMinOrMax{OperationBaseFloat{o.OperationBase, o.Total}, o.cvalue}
+}
+
+// Merge checks if the Total value of other operation is more great or less than the
+// value of the struct.
+func (o *OperationFloatMinOrMax) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperationFloatMinOrMax)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	if (simpleFloatType{op.Total}).Compare(o.Total) == o.cvalue {
+		o.Total = op.Total
+	}
+
+	return nil
+}
+
 // OperationIntMinOrMax is a struct that calculates the min or
 // the max of a Dataframe column of type complex
+//
+// Deprecated: use NewMinAggregator or NewMaxAggregator.
 type OperationComplexMinOrMax struct {
 	OperationBaseComplex
 	cvalue Comparers
@@ -222,6 +466,10 @@ type OperationComplexMinOrMax struct {
 // F checks if column value in the row is more great or less than the value of the struct.
 func (o *OperationComplexMinOrMax)F(r *Row) error {
 	v, _ := r.Cell(o.colName)
+	if v.IsNull() {
+		return nil
+	}
+
 	ctype, _ := v.ComplexType()
 
 	if ctype.Compare(o.Total) == o.cvalue {
@@ -231,120 +479,233 @@ func (o *OperationComplexMinOrMax)F(r *Row) error {
 	return nil
 }
 
-// operationMinOrMax returns the min or max, depending of the bool isMin, of the DataFrame column,
-// in the range rows between min or max parameters.
-func (df *DataFrame) operationMinOrMax(
-	isMin bool, colName string, min, max int,
-) (interface{}, error) {
-	column, exists := df.getColumnByName(colName)
-	if !exists {
-		return nil, fmt.Errorf("column %s not found", colName)
+// New returns a new operation of the same column, using the current Total value as
+// initial value.
+func (o *OperationComplexMinOrMax) New() MergeableOperation {
+	return &OperationComplexMinOrMax{OperationBaseComplex{o.OperationBase, o.Total}, o.cvalue}
+}
+
+// Merge checks if the Total value of other operation is more great or less than the
+// value of the struct.
+func (o *OperationComplexMinOrMax) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperationComplexMinOrMax)
+	if !ok {
+		return errors.New("the operations can't be merged")
 	}
 
-	comparer := LESS
-	if !isMin {
-		comparer = GREAT
+	if (simpleComplexType{op.Total}).Compare(o.Total) == o.cvalue {
+		o.Total = op.Total
 	}
 
-	switch column.ctype {
-	case INT:
-		var value int64 = math.MaxInt64
-		if !isMin {
-			value = math.MinInt64
-		}
+	return nil
+}
 
-		op := OperationIntMinOrMax{
-			OperationBaseInt{OperationBase{colName}, value},
-			comparer,
-		}
+// minMaxResult transforms the result of a min or max aggregator in the returned value.
+// Returns an error if the result is null, because the column hasn't values.
+func minMaxResult(value Value, colName string) (interface{}, error) {
+	if value.IsNull() {
+		return nil, fmt.Errorf("the column %s hasn't values", colName)
+	}
 
-		if err := df.OperationRange(&op, min, max); err != nil {
-			return int64(0), err
-		}
+	return value.rawValue(), nil
+}
 
-		return op.Total, nil
+// MaxRange returns the max of the colName DataFrame column, in the range rows between min and
+// max parameters. The values are compared with the Compare function of the column type, so
+// the custom types use their own order. The null values and the NaN floats are ignored.
+//
+// The value returned depends of the column type: int64, uint64, float64, complex128 or
+// string. Returns an error if the column doesn't exist or it hasn't values in the range.
+func (df *DataFrame) MaxRange(colName string, min, max int) (interface{}, error) {
+	value, err := df.AggregateRange(NewMaxAggregator(colName), min, max)
+	if err != nil {
+		return nil, err
+	}
 
-	case UINT:
-		var value uint64 = math.MaxUint64
-		if !isMin {
-			value = 0
-		}
+	return minMaxResult(value, colName)
+}
 
-		op := OperationUintMinOrMax{
-			OperationBaseUint{OperationBase{colName}, value},
-			comparer,
-		}
+// Max returns the max of the colName DataFrame column. See MaxRange.
+func (df *DataFrame) Max(colName string) (interface{}, error) {
+	return df.MaxRange(colName, 0, df.NumberRows())
+}
 
-		if err := df.OperationRange(&op, min, max); err != nil {
-			return uint64(0), err
-		}
+// MinRange returns the min of the colName DataFrame column, in the range rows between min and
+// max parameters. The values are compared with the Compare function of the column type, so
+// the custom types use their own order. The null values and the NaN floats are ignored.
+//
+// The value returned depends of the column type: int64, uint64, float64, complex128 or
+// string. Returns an error if the column doesn't exist or it hasn't values in the range.
+func (df *DataFrame) MinRange(colName string, min, max int) (interface{}, error) {
+	value, err := df.AggregateRange(NewMinAggregator(colName), min, max)
+	if err != nil {
+		return nil, err
+	}
 
-		return op.Total, nil
+	return minMaxResult(value, colName)
+}
 
-	case FLOAT:
-		var value float64 = math.MaxFloat64
-		if !isMin {
-			value = math.SmallestNonzeroFloat64
-		}
+// Min returns the min of the colName DataFrame column. See MinRange.
+func (df *DataFrame) Min(colName string) (interface{}, error) {
+	return df.MinRange(colName, 0, df.NumberRows())
+}
 
-		op := OperationFloatMinOrMax{
-			OperationBaseFloat{OperationBase{colName}, value},
-			comparer,
-		}
-		if err := df.OperationRange(&op, min, max); err != nil {
-			return float64(0), err
-		}
-		return op.Total, nil
+// minMaxOperation operation calculates the min and the max of a column in one pass.
+type minMaxOperation struct {
+	min *minMaxAggregator
+	max *minMaxAggregator
+}
 
-	case COMPLEX:
-		var value complex128 = complex(math.MaxFloat64, math.MaxFloat64)
-		if !isMin {
-			value = complex(math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64)
-		}
+// F compares the value of the row with the current min and max.
+func (o *minMaxOperation) F(r *Row) error {
+	v, err := r.Cell(o.min.colName)
+	if err != nil {
+		return err
+	}
 
-		op := OperationComplexMinOrMax{
-			OperationBaseComplex{OperationBase{colName}, value},
-			comparer,
-		}
-		if err := df.OperationRange(&op, min, max); err != nil {
-			return 0 + 0i, err
-		}
+	o.min.update(v)
+	o.max.update(v)
+	return nil
+}
+
+// MinMaxRange returns the min and the max of the colName DataFrame column, in the range rows
+// between min and max parameters, reading the rows only once. The values are compared like in
+// MinRange and MaxRange. Returns an error if the column doesn't exist or it hasn't values in
+// the range.
+func (df *DataFrame) MinMaxRange(colName string, min, max int) (interface{}, interface{}, error) {
+	op := minMaxOperation{
+		NewMinAggregator(colName).(*minMaxAggregator),
+		NewMaxAggregator(colName).(*minMaxAggregator),
+	}
 
-		return op.Total, nil
+	if err := op.min.Init(df); err != nil {
+		return nil, nil, err
+	}
+
+	if err := op.max.Init(df); err != nil {
+		return nil, nil, err
+	}
 
-	default:
-		return nil, fmt.Errorf("Sum operation is invalid in column type %s", column.ctype)
+	if err := df.OperationRange(&op, min, max); err != nil {
+		return nil, nil, err
+	}
+
+	minValue, err := minMaxResult(op.min.Result(), colName)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	maxValue, _ := minMaxResult(op.max.Result(), colName)
+	return minValue, maxValue, nil
 }
 
-// MaxRange returns the max of the colName DataFrame column,
-// in the range rows between min or max parameters.
-func (df *DataFrame) MaxRange(colName string, min, max int) (interface{}, error) {
-	return df.operationMinOrMax(false, colName, min, max)
+// MinMax returns the min and the max of the colName DataFrame column, reading the rows only
+// once. See MinMaxRange.
+func (df *DataFrame) MinMax(colName string) (interface{}, interface{}, error) {
+	return df.MinMaxRange(colName, 0, df.NumberRows())
 }
 
-// Max returns the max of the colName DataFrame column,
-func (df *DataFrame) Max(colName string) (interface{}, error) {
-	return df.operationMinOrMax(false, colName, 0, df.NumberRows())
+// OperationMean is a struct used to calculate the arithmetic mean of a DataFrame column
+// type int, uint or float. The null values are ignored.
+//
+// Deprecated: use NewMeanAggregator.
+type OperationMean struct {
+	OperationBase
+	Sum   float64 // sum of the column values.
+	Count int     // number of values summed.
 }
 
-// MinRange returns the max of the colName DataFrame column,
-// in the range rows between min or max parameters.
-func (df *DataFrame) MinRange(colName string, min, max int) (interface{}, error) {
-	return df.operationMinOrMax(true, colName, min, max)
+// F sums the value of the Cell colName, fetched from r, with the Sum value.
+func (o *OperationMean) F(r *Row) error {
+	v, _ := r.Cell(o.colName)
+	if v.IsNull() {
+		return nil
+	}
+
+	number, err := v.toNumber()
+	if err != nil {
+		return fmt.Errorf("Mean operation is invalid in column %s", o.colName)
+	}
+
+	o.Sum += number
+	o.Count++
+	return nil
 }
 
-// Min returns the max of the colName DataFrame column,
-func (df *DataFrame) Min(colName string) (interface{}, error) {
-	return df.operationMinOrMax(true, colName, 0, df.NumberRows())
+// New returns a new mean operation of the same column.
+func (o *OperationMean) New() MergeableOperation {
+	return &OperationMean{o.OperationBase, 0, 0}
+}
+
+// Merge adds the sum and the count of other operation to the operation.
+func (o *OperationMean) Merge(other MergeableOperation) error {
+	op, ok := other.(*OperationMean)
+	if !ok {
+		return errors.New("the operations can't be merged")
+	}
+
+	o.Sum += op.Sum
+	o.Count += op.Count
+	return nil
+}
+
+// Result returns the mean. It returns an error if there aren't values.
+func (o *OperationMean) Result() (float64, error) {
+	if o.Count == 0 {
+		return 0, errors.New("there aren't values to calculate the mean")
+	}
+
+	return o.Sum / float64(o.Count), nil
+}
+
+// MeanRange returns the arithmetic mean of the colName DataFrame column,
+// in the range rows between min or max parameters. The column must be type int, uint, float or
+// decimal. The mean is always a float64, also in the decimal columns; use MeanDecimalRange to
+// get the exact mean of a decimal column as a Decimal.
+func (df *DataFrame) MeanRange(colName string, min, max int) (float64, error) {
+	value, err := df.AggregateRange(NewMeanAggregator(colName), min, max)
+	if err != nil {
+		return 0, err
+	}
+
+	if value.IsNull() {
+		return 0, errors.New("there aren't values to calculate the mean")
+	}
+
+	return value.toNumber()
+}
+
+// Mean returns the arithmetic mean of the colName DataFrame column. The mean is a float64, also
+// in the decimal columns; use MeanDecimal to get the exact mean of a decimal column.
+func (df *DataFrame) Mean(colName string) (float64, error) {
+	return df.MeanRange(colName, 0, df.NumberRows())
 }
 
 /*
 Operation interface it is used to craete custom operations with the DataFrame rows.
 This interface is used in combination with the DataFrame method Operation and OperationRange.
+
+The operations are executed while the DataFrame is locked for reading, so the F function only
+must use the row passed as parameter, and never call to the DataFrame methods.
+
+The Aggregator interface supersedes this interface. The operations can be used as aggregators
+with the OperationAggregator adapter.
 */
 type Operation interface {
 	// F Function will execute in each iteration. In each iteration it is passed the
 	// current row of the DataFrame iterator.
 	F(*Row) error
 }
+
+// MergeableOperation interface is an Operation that can be executed in parallel, using the
+// DataFrame methods OperationParallel and OperationParallelRange. The rows are split in
+// several ranges, each range is processed by a new operation and, at last, the partial
+// results are merged.
+type MergeableOperation interface {
+	Operation
+	// New returns a new operation, with the same configuration, to process a range of rows.
+	New() MergeableOperation
+	// Merge merges the partial result of the other operation, returned by New, in the
+	// operation.
+	Merge(other MergeableOperation) error
+}
@@ -0,0 +1,269 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// nullsOrder indicates where the null values are placed when the DataFrame rows are ordered.
+type nullsOrder int8
+
+// The valid positions of the null values.
+const (
+	NULLS_LAST  nullsOrder = 0
+	NULLS_FIRST nullsOrder = 1
+)
+
+// OrderOptions struct defines how the values of a column are compared when the DataFrame
+// rows are ordered. The zero value compares the values using the Compare function of
+// the column type and places the null values at the end. Without a custom comparer, the NaN
+// floats are placed after the numbers, whatever the order type.
+type OrderOptions struct {
+	// Comparer is a custom function to compare the column values. If it is defined,
+	// the Collator and Magnitude options are ignored. The null values are never passed
+	// to the function.
+	Comparer func(a, b Value) Comparers
+	// Collator defines the order of the string columns.
+	Collator StringCollator
+	// Magnitude flag orders the numeric columns by the absolute value of the numbers.
+	// The complex numbers are ordered by its magnitude.
+	Magnitude bool
+	// Nulls defines where the null values are placed: NULLS_LAST or NULLS_FIRST.
+	// The position doesn't depend of the order type.
+	Nulls nullsOrder
+}
+
+/*
+OrderColumnOptions struct is used to define the order of a DataFrame column with
+advanced options.
+
+Example:
+	df.OrderWithOptions(
+		OrderColumn{"name", ASC}.WithOptions(OrderOptions{Collator: UnicodeCollator{}}),
+		OrderColumn{"age", DESC}.WithOptions(OrderOptions{Nulls: NULLS_FIRST}),
+	)
+*/
+type OrderColumnOptions struct {
+	OrderColumn
+	OrderOptions
+}
+
+// WithOptions returns the order column with the opts options.
+func (oc OrderColumn) WithOptions(opts OrderOptions) OrderColumnOptions {
+	return OrderColumnOptions{oc, opts}
+}
+
+// check checks if the order options are valid to order the col column.
+func (o *OrderOptions) check(col *column) error {
+	if o.Nulls != NULLS_LAST && o.Nulls != NULLS_FIRST {
+		return fmt.Errorf("invalid nulls order in column %s", col.name)
+	}
+
+	if o.Comparer != nil {
+		return nil
+	}
+
+	if o.Collator != nil && col.ctype != STRING && col.ctype != CATEGORY {
+		return fmt.Errorf("the collator is invalid in column %s of type %s", col.name, col.ctype)
+	}
+
+	if o.Magnitude && (col.ctype == STRING || col.ctype == CATEGORY) {
+		return fmt.Errorf("the magnitude order is invalid in column %s of type %s",
+			col.name, col.ctype)
+	}
+
+	return nil
+}
+
+// columnComparer returns a function to compare two values of a column of ctype type, using
+// the Compare function of the value. The floats are compared with compareFloats, so the NaN
+// values are greater than all numbers.
+func columnComparer(ctype columnType) func(a, b Value) (Comparers, error) {
+	switch ctype {
+	case INT:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.IntType()
+			v, _ := b.Int64()
+			return i.Compare(v), nil
+		}
+	case UINT:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.UintType()
+			v, _ := b.Uint64()
+			return i.Compare(v), nil
+		}
+	case FLOAT:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Float64()
+			v, _ := b.Float64()
+			return compareFloats(i, v), nil
+		}
+	case COMPLEX:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.ComplexType()
+			v, _ := b.Complex128()
+			return i.Compare(v), nil
+		}
+	case STRING, CATEGORY:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.StringType()
+			v, _ := b.Str()
+			return i.Compare(v), nil
+		}
+	case DECIMAL:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.DecimalType()
+			v, _ := b.Decimal()
+			return i.Compare(v), nil
+		}
+	default:
+		return nil
+	}
+}
+
+// toComparers transforms the result of a compare function in a Comparers value.
+func toComparers(c int) Comparers {
+	if c < 0 {
+		return LESS
+	} else if c > 0 {
+		return GREAT
+	}
+
+	return EQUAL
+}
+
+// compareFloats compares the a and b floats. The NaN values are greater than all numbers and
+// equal between them, so the floats keep a strict weak order.
+func compareFloats(a, b float64) Comparers {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return EQUAL
+	case aNaN:
+		return GREAT
+	case bNaN:
+		return LESS
+	case a < b:
+		return LESS
+	case a > b:
+		return GREAT
+	}
+
+	return EQUAL
+}
+
+// absInt returns the absolute value of i as uint64, so it doesn't overflow with MinInt64.
+func absInt(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+
+	return uint64(i)
+}
+
+// magnitudeComparer returns a function to compare two values of a column of ctype type
+// using the absolute value of the numbers.
+func magnitudeComparer(ctype columnType) func(a, b Value) (Comparers, error) {
+	switch ctype {
+	case INT:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Int64()
+			v, _ := b.Int64()
+			return simpleUintType{absInt(i)}.Compare(absInt(v)), nil
+		}
+	case FLOAT:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Float64()
+			v, _ := b.Float64()
+			return compareFloats(math.Abs(i), math.Abs(v)), nil
+		}
+	case COMPLEX:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Complex128()
+			v, _ := b.Complex128()
+			return compareFloats(cmplx.Abs(i), cmplx.Abs(v)), nil
+		}
+	case DECIMAL:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Decimal()
+			v, _ := b.Decimal()
+			return Comparers(new(big.Rat).Abs(i.rat()).Cmp(new(big.Rat).Abs(v.rat()))), nil
+		}
+	default:
+		// the uint values are always positive.
+		return columnComparer(ctype)
+	}
+}
+
+// valueComparer returns the function to compare two not null values of the column,
+// depending of the order options.
+func (oc *internalOrderColumn) valueComparer() func(a, b Value) (Comparers, error) {
+	opts := oc.options
+
+	switch {
+	case opts.Comparer != nil:
+		return func(a, b Value) (Comparers, error) {
+			return toComparers(int(opts.Comparer(a, b))), nil
+		}
+	case opts.Collator != nil:
+		return func(a, b Value) (Comparers, error) {
+			i, _ := a.Str()
+			v, _ := b.Str()
+			return toComparers(opts.Collator.CompareString(i, v)), nil
+		}
+	case opts.Magnitude:
+		return magnitudeComparer(oc.column.ctype)
+	default:
+		return columnComparer(oc.column.ctype)
+	}
+}
+
+// compare compares the values a and b of the column using the f function, made with
+// valueComparer. It applies the position of the null values and the order type, so it
+// returns LESS whether a must be placed before b.
+func (oc *internalOrderColumn) compare(
+	f func(a, b Value) (Comparers, error), a, b Value,
+) (Comparers, error) {
+	anull, bnull := a.IsNull(), b.IsNull()
+
+	switch {
+	case anull && bnull:
+		return EQUAL, nil
+	case anull:
+		if oc.options.Nulls == NULLS_FIRST {
+			return LESS, nil
+		}
+		return GREAT, nil
+	case bnull:
+		if oc.options.Nulls == NULLS_FIRST {
+			return GREAT, nil
+		}
+		return LESS, nil
+	}
+
+	if oc.options.Comparer == nil && (oc.column.ctype == FLOAT || oc.column.ctype == COMPLEX) {
+		// the NaN values are after the numbers, like the nulls, in both order types.
+		anan, bnan := isNaNValue(a), isNaNValue(b)
+		switch {
+		case anan && bnan:
+			return EQUAL, nil
+		case anan:
+			return GREAT, nil
+		case bnan:
+			return LESS, nil
+		}
+	}
+
+	comp, err := f(a, b)
+	if err != nil {
+		return EQUAL, err
+	}
+
+	if oc.order == DESC {
+		return -comp, nil
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,253 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_OrderOptions_check_func(t *testing.T) {
+	as := assert.New(t)
+	icol := column{"i", INT, 0, true, ColumnMeta{}}
+	scol := column{"s", STRING, 1, true, ColumnMeta{}}
+
+	opts := OrderOptions{}
+	as.Nil(opts.check(&icol), "the options are valid")
+
+	opts = OrderOptions{Collator: UnicodeCollator{}}
+	as.Nil(opts.check(&scol), "the options are valid")
+	as.Equal(
+		"the collator is invalid in column i of type int",
+		opts.check(&icol).Error(), "the error message doesn't match")
+
+	opts = OrderOptions{Magnitude: true}
+	as.Nil(opts.check(&icol), "the options are valid")
+	as.Equal(
+		"the magnitude order is invalid in column s of type string",
+		opts.check(&scol).Error(), "the error message doesn't match")
+
+	// the comparer ignores the rest of options.
+	opts = OrderOptions{Comparer: func(a, b Value) Comparers { return EQUAL }, Magnitude: true}
+	as.Nil(opts.check(&scol), "the options are valid")
+
+	opts = OrderOptions{Nulls: 3}
+	as.Equal("invalid nulls order in column i", opts.check(&icol).Error(),
+		"the error message doesn't match")
+}
+
+func Test_DataFrame_OrderWithOptions_Collator(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	data := []struct {
+		S string `colName:"s"`
+	}{
+		{"file10"}, {"File2"}, {"file1"}, {"file3"},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	err := df.OrderWithOptions(
+		OrderColumn{"s", ASC}.WithOptions(OrderOptions{Collator: NaturalCollator{true}}))
+	if err != nil {
+		as.FailNowf("error ordering the DataFrame", "error: %s", err.Error())
+		return
+	}
+
+	values, _ := df.ColumnAsString("s")
+	as.Equal([]string{"file1", "File2", "file3", "file10"}, values, "the order is wrong")
+
+	// collator in a column that is not a string.
+	df = makeDataFrame([]mockData{{1, 1}}, t)
+	err = df.OrderWithOptions(
+		OrderColumn{"a", ASC}.WithOptions(OrderOptions{Collator: NaturalCollator{}}))
+	as.Equal("the collator is invalid in column a of type int", err.Error(),
+		"the error message doesn't match")
+
+	// column not found.
+	err = df.OrderWithOptions(OrderColumn{"c", ASC}.WithOptions(OrderOptions{}))
+	as.Equal("The column c doesn't exists", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_OrderWithOptions_Invalid(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeDataFrame([]mockData{{2, 1}, {1, 2}, {3, 3}}, t); df == nil {
+		return
+	}
+
+	as.Nil(df.Order(OrderColumn{"a", DESC}), "error ordering the DataFrame")
+
+	// the second column is invalid, so the order of the DataFrame isn't changed.
+	err := df.OrderWithOptions(
+		OrderColumn{"b", ASC}.WithOptions(OrderOptions{}),
+		OrderColumn{"c", ASC}.WithOptions(OrderOptions{}))
+	as.Equal("The column c doesn't exists", err.Error(), "the error message doesn't match")
+	as.Len(df.order, 1, "the order is changed")
+	as.Equal("a", df.order[0].column.name, "the order is changed")
+	as.Equal(DESC, df.order[0].order, "the order is changed")
+
+	values, _ := df.ColumnAsInt("a")
+	as.Equal([]int64{3, 2, 1}, values, "the rows are reordered")
+}
+
+func Test_DataFrame_OrderWithOptions_Magnitude(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	data := []struct {
+		I int        `colName:"i"`
+		F float64    `colName:"f"`
+		C complex128 `colName:"c"`
+	}{
+		{-3, 2.5, 3 + 4i},
+		{1, -3.5, 1},
+		{-2, 0.5, -2i},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	magnitude := OrderOptions{Magnitude: true}
+
+	df.OrderWithOptions(OrderColumn{"i", ASC}.WithOptions(magnitude))
+	ints, _ := df.ColumnAsInt("i")
+	as.Equal([]int64{1, -2, -3}, ints, "the order is wrong")
+
+	df.OrderWithOptions(OrderColumn{"f", DESC}.WithOptions(magnitude))
+	floats, _ := df.ColumnAsFloat("f")
+	as.Equal([]float64{-3.5, 2.5, 0.5}, floats, "the order is wrong")
+
+	df.OrderWithOptions(OrderColumn{"c", ASC}.WithOptions(magnitude))
+	complexes, _ := df.ColumnAsComplex("c")
+	as.Equal([]complex128{1, -2i, 3 + 4i}, complexes, "the order is wrong")
+}
+
+func Test_compareFloats_func(t *testing.T) {
+	as := assert.New(t)
+	nan := math.NaN()
+
+	as.Equal(LESS, compareFloats(1, 2), "the comparison is wrong")
+	as.Equal(GREAT, compareFloats(math.Inf(1), 2), "the comparison is wrong")
+	as.Equal(EQUAL, compareFloats(-0.0, 0), "the comparison is wrong")
+	as.Equal(GREAT, compareFloats(nan, math.Inf(1)), "the NaN is greater than the numbers")
+	as.Equal(LESS, compareFloats(math.Inf(1), nan), "the NaN is greater than the numbers")
+	as.Equal(EQUAL, compareFloats(nan, nan), "the NaN values are equal")
+}
+
+func Test_DataFrame_OrderWithOptions_Magnitude_NaN(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	nan := math.NaN()
+	data := []struct {
+		F float64 `colName:"f"`
+	}{{nan}, {-3}, {nan}, {2}, {math.Inf(-1)}, {nan}, {-1}}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	magnitude := OrderOptions{Magnitude: true}
+
+	df.OrderWithOptions(OrderColumn{"f", ASC}.WithOptions(magnitude))
+	as.Equal([]string{"-1", "2", "-3", "-Inf", "NaN", "NaN", "NaN"}, columnStrings(df, "f"),
+		"the NaN values are after the numbers")
+
+	df.OrderWithOptions(OrderColumn{"f", DESC}.WithOptions(magnitude))
+	as.Equal([]string{"-Inf", "-3", "2", "-1", "NaN", "NaN", "NaN"}, columnStrings(df, "f"),
+		"the NaN values are after the numbers")
+}
+
+func Test_DataFrame_Order_NaN(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	nan := math.NaN()
+	data := []struct {
+		F *float64 `colName:"f"`
+	}{{floatPtr(3)}, {&nan}, {floatPtr(1)}, {nil}, {floatPtr(4)}, {&nan}, {floatPtr(2)},
+		{floatPtr(0)}}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	df.Order(OrderColumn{"f", ASC})
+	as.Equal([]string{"0", "1", "2", "3", "4", "NaN", "NaN", ""}, columnStrings(df, "f"),
+		"the NaN values are after the numbers")
+
+	df.Order(OrderColumn{"f", DESC})
+	as.Equal([]string{"4", "3", "2", "1", "0", "NaN", "NaN", ""}, columnStrings(df, "f"),
+		"the NaN values are after the numbers")
+
+	df.OrderWithOptions(OrderColumn{"f", DESC}.WithOptions(OrderOptions{Nulls: NULLS_FIRST}))
+	as.Equal([]string{"", "4", "3", "2", "1", "0", "NaN", "NaN"}, columnStrings(df, "f"),
+		"the NaN values are after the numbers")
+}
+
+func Test_DataFrame_OrderWithOptions_Comparer(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	data := []mockData{{1, 1}, {2, 2}, {3, 3}, {4, 4}}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	// even numbers first.
+	comparer := func(a, b Value) Comparers {
+		i, _ := a.Int()
+		j, _ := b.Int()
+		if i%2 != j%2 {
+			return toComparers(i%2 - j%2)
+		}
+
+		return toComparers(i - j)
+	}
+
+	df.OrderWithOptions(OrderColumn{"a", ASC}.WithOptions(OrderOptions{Comparer: comparer}))
+	values, _ := df.ColumnAsInt("a")
+	as.Equal([]int64{2, 4, 1, 3}, values, "the order is wrong")
+
+	df.OrderWithOptions(OrderColumn{"a", DESC}.WithOptions(OrderOptions{Comparer: comparer}))
+	values, _ = df.ColumnAsInt("a")
+	as.Equal([]int64{3, 1, 4, 2}, values, "the order is wrong")
+}
+
+func Test_DataFrame_OrderWithOptions_Nulls(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	one, two, three := 1, 2, 3
+	data := []struct {
+		A *int `colName:"a"`
+	}{
+		{&two}, {nil}, {&three}, {nil}, {&one},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	// get the column values as strings. The nulls are empty strings.
+	values := func() []string {
+		var strs []string
+		column, _ := df.Column("a")
+		for _, v := range column {
+			strs = append(strs, v.String())
+		}
+		return strs
+	}
+
+	df.Order(OrderColumn{"a", ASC})
+	as.Equal([]string{"1", "2", "3", "", ""}, values(), "the order is wrong")
+
+	df.Order(OrderColumn{"a", DESC})
+	as.Equal([]string{"3", "2", "1", "", ""}, values(), "the order is wrong")
+
+	df.OrderWithOptions(OrderColumn{"a", ASC}.WithOptions(OrderOptions{Nulls: NULLS_FIRST}))
+	as.Equal([]string{"", "", "1", "2", "3"}, values(), "the order is wrong")
+
+	df.OrderWithOptions(OrderColumn{"a", DESC}.WithOptions(OrderOptions{Nulls: NULLS_FIRST}))
+	as.Equal([]string{"", "", "3", "2", "1"}, values(), "the order is wrong")
+}
@@ -0,0 +1,211 @@
+package dataframe
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ValueParser interface is implemented by the custom *ValueTypes* that can be made from a
+// string, like the strings returned by its String function. It is used to populate the
+// custom types from text sources, like a CSV file.
+//
+// Example:
+//	func (k *Kelvin) Parse(str string) error {
+//		v, err := strconv.ParseFloat(str, 64)
+//		k.value = v - 273
+//		return err
+//	}
+//
+// The custom types can implement the encoding.TextUnmarshaler interface too.
+type ValueParser interface {
+	// Parse sets the value of the struct from the str string.
+	Parse(str string) error
+}
+
+// ParseValue parses the str string as a value of the ctype column type. The str string must
+// have the format returned by the String function of the value. The empty strings are parsed
+// as null values, except in the STRING columns.
+func ParseValue(ctype columnType, str string) (Value, error) {
+	var err error
+	var v Value
+
+	if str == "" && ctype != STRING {
+		if _, err = getColumnTypeFromString(string(ctype)); err != nil {
+			return Value{}, err
+		}
+
+		return Value{}, nil
+	}
+
+	switch ctype {
+	case INT:
+		var i int64
+		i, err = strconv.ParseInt(str, 10, 64)
+		v = newIntValue(i)
+	case UINT:
+		var u uint64
+		u, err = strconv.ParseUint(str, 10, 64)
+		v = newUintValue(u)
+	case FLOAT:
+		var f float64
+		f, err = strconv.ParseFloat(str, 64)
+		v = newFloatValue(f)
+	case COMPLEX:
+		var c complex128
+		c, err = strconv.ParseComplex(str, 128)
+		v = newComplexValue(c)
+	case STRING:
+		v = newStringValue(str)
+	case DECIMAL:
+		var d Decimal
+		d, err = ParseDecimal(str)
+		v = newDecimalValue(d)
+	default:
+		return Value{}, fmt.Errorf("%s is an invalid type", ctype)
+	}
+
+	if err != nil {
+		return Value{}, fmt.Errorf("%s is not a valid %s value", str, ctype)
+	}
+
+	return v, nil
+}
+
+// parseCustomValue creates a new value of the t custom type from the str string. t must be a
+// struct type whose pointer implements a *ValueTypes* interface and the ValueParser or
+// encoding.TextUnmarshaler interfaces.
+func parseCustomValue(t reflect.Type, str string) (Value, error) {
+	ptr := reflect.New(t)
+
+	switch p := ptr.Interface().(type) {
+	case ValueParser:
+		if err := p.Parse(str); err != nil {
+			return Value{}, err
+		}
+	case encoding.TextUnmarshaler:
+		if err := p.UnmarshalText([]byte(str)); err != nil {
+			return Value{}, err
+		}
+	default:
+		return Value{}, fmt.Errorf("the type %s can't be parsed", t)
+	}
+
+	v, err := newValue(ptr.Interface())
+	if err != nil {
+		return Value{}, fmt.Errorf("the type %s isn't a value type", t)
+	}
+
+	return *v, nil
+}
+
+// valueTypesRegistry stores the custom types registered with RegisterValueType.
+var valueTypesRegistry = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}{types: map[string]reflect.Type{}, names: map[reflect.Type]string{}}
+
+// RegisterValueType registers the custom type of the value param with the name param. Then
+// the registered type can be parsed from a string using ParseCustomValue, and the readers,
+// like the csv DataFrames and the Builder, rebuild the custom columns whose ValueType
+// metadata is the type name.
+//
+// The value param is a value or a pointer of the custom type, and its pointer must implement
+// one *ValueTypes* interface and the ValueParser or encoding.TextUnmarshaler interfaces.
+// Returns an error if the type is invalid or the name is already registered.
+//
+// Example:
+//	err := RegisterValueType("kelvin", Kelvin{})
+//	v, err := ParseCustomValue("kelvin", "300")
+func RegisterValueType(name string, value interface{}) error {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return fmt.Errorf("the value type is nil")
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	ptr := reflect.New(t).Interface()
+	if _, err := newValue(ptr); err != nil {
+		return fmt.Errorf("the type %s isn't a value type", t)
+	}
+
+	_, isParser := ptr.(ValueParser)
+	_, isUnmarshaler := ptr.(encoding.TextUnmarshaler)
+	if !isParser && !isUnmarshaler {
+		return fmt.Errorf("the type %s can't be parsed", t)
+	}
+
+	valueTypesRegistry.Lock()
+	defer valueTypesRegistry.Unlock()
+
+	if _, exists := valueTypesRegistry.types[name]; exists {
+		return fmt.Errorf("the type %s is already registered", name)
+	}
+
+	valueTypesRegistry.types[name] = t
+	valueTypesRegistry.names[t] = name
+	return nil
+}
+
+// ParseCustomValue parses the str string as a value of the custom type registered with the
+// name param. Returns an error if the type isn't registered or the string is invalid.
+func ParseCustomValue(name string, str string) (Value, error) {
+	valueTypesRegistry.RLock()
+	t, exists := valueTypesRegistry.types[name]
+	valueTypesRegistry.RUnlock()
+
+	if !exists {
+		return Value{}, fmt.Errorf("the type %s isn't registered", name)
+	}
+
+	return parseCustomValue(t, str)
+}
+
+// ValueTypeName returns the name of the registered custom type of the v value. Returns false
+// if the type of the value isn't registered.
+func ValueTypeName(v Value) (string, bool) {
+	return registeredTypeName(reflect.TypeOf(v.value))
+}
+
+// registeredTypeName returns the name of the t custom type, or the type pointed by t, if it is
+// registered.
+func registeredTypeName(t reflect.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	valueTypesRegistry.RLock()
+	defer valueTypesRegistry.RUnlock()
+
+	name, exists := valueTypesRegistry.names[t]
+	return name, exists
+}
+
+// checkValueType checks if the custom type registered with the name param can be stored in a
+// column of ctype type.
+func checkValueType(name string, ctype columnType) error {
+	valueTypesRegistry.RLock()
+	t, exists := valueTypesRegistry.types[name]
+	valueTypesRegistry.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("the type %s isn't registered", name)
+	}
+
+	v, _ := newValue(reflect.New(t).Interface())
+	if v.Kind() != ctype {
+		return fmt.Errorf("the type %s is %s, not %s", name, v.Kind(), ctype)
+	}
+
+	return nil
+}
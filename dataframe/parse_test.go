@@ -0,0 +1,185 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// kelvinType is a custom float type used in the parse tests.
+type kelvinType struct {
+	value float64
+}
+
+func (k *kelvinType) String() string {
+	return fmt.Sprintf("%gK", k.value)
+}
+
+func (k *kelvinType) Value() float64 {
+	return k.value
+}
+
+func (k *kelvinType) Compare(v float64) Comparers {
+	return simpleFloatType{k.value}.Compare(v)
+}
+
+func (k *kelvinType) Parse(str string) error {
+	if len(str) == 0 || str[len(str)-1] != 'K' {
+		return errors.New("invalid kelvin")
+	}
+
+	v, err := strconv.ParseFloat(str[:len(str)-1], 64)
+	k.value = v
+	return err
+}
+
+// codeType is a custom string type, implementing encoding.TextUnmarshaler, used in the
+// parse tests.
+type codeType struct {
+	value string
+}
+
+func (c *codeType) String() string {
+	return c.value
+}
+
+func (c *codeType) Value() string {
+	return c.value
+}
+
+func (c *codeType) Compare(v string) Comparers {
+	return simpleStringType{c.value}.Compare(v)
+}
+
+func (c *codeType) UnmarshalText(text []byte) error {
+	c.value = "#" + string(text)
+	return nil
+}
+
+func Test_ParseValue_func(t *testing.T) {
+	as := assert.New(t)
+
+	cases := []struct {
+		ctype  columnType
+		str    string
+		result Value
+	}{
+		{INT, "-12", newIntValue(-12)},
+		{UINT, "12", newUintValue(12)},
+		{FLOAT, "1.5", newFloatValue(1.5)},
+		{COMPLEX, "1+2i", newComplexValue(1 + 2i)},
+		{STRING, "abc", newStringValue("abc")},
+		{STRING, "", newStringValue("")},
+		{INT, "", Value{}},
+	}
+
+	for _, c := range cases {
+		v, err := ParseValue(c.ctype, c.str)
+		as.Nil(err, "there is an error parsing %s", c.str)
+		as.Equal(c.result, v, "the value %s is wrong", c.str)
+		as.Equal(c.str, v.String(), "the parsed value isn't the inverse of String")
+	}
+
+	_, err := ParseValue(INT, "1.5")
+	as.Equal("1.5 is not a valid int value", err.Error(), "the error message isn't match")
+	_, err = ParseValue(UINT, "-1")
+	as.Equal("-1 is not a valid uint value", err.Error(), "the error message isn't match")
+	_, err = ParseValue(columnType("date"), "")
+	as.Equal("date is an invalid type", err.Error(), "the error message isn't match")
+}
+
+func Test_RegisterValueType_func(t *testing.T) {
+	as := assert.New(t)
+
+	as.Nil(RegisterValueType("test-kelvin", kelvinType{}), "error registering the type")
+	as.Nil(RegisterValueType("test-code", &codeType{}), "error registering the type")
+
+	err := RegisterValueType("test-kelvin", kelvinType{})
+	as.Equal("the type test-kelvin is already registered", err.Error(),
+		"the error message isn't match")
+	err = RegisterValueType("test-int", 3)
+	as.Equal("the type int isn't a value type", err.Error(), "the error message isn't match")
+	err = RegisterValueType("test-simple", simpleIntType{})
+	as.Equal("the type dataframe.simpleIntType can't be parsed", err.Error(),
+		"the error message isn't match")
+
+	v, err := ParseCustomValue("test-kelvin", "300K")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(FLOAT, v.Kind(), "the value kind is wrong")
+	f, _ := v.Float64()
+	as.Equal(300.0, f, "the value is wrong")
+
+	name, ok := ValueTypeName(v)
+	as.True(ok, "the type is registered")
+	as.Equal("test-kelvin", name, "the type name is wrong")
+
+	v, err = ParseCustomValue("test-code", "a1")
+	as.Nil(err, "there is an error in the function")
+	as.Equal("#a1", v.String(), "the value is wrong")
+
+	_, err = ParseCustomValue("test-kelvin", "300")
+	as.Equal("invalid kelvin", err.Error(), "the error message isn't match")
+	_, err = ParseCustomValue("celsius", "300")
+	as.Equal("the type celsius isn't registered", err.Error(), "the error message isn't match")
+
+	_, ok = ValueTypeName(newIntValue(1))
+	as.False(ok, "the type isn't registered")
+}
+
+func Test_column_parseString_ValueType(t *testing.T) {
+	as := assert.New(t)
+
+	// the type may be registered by a previous run of the test.
+	RegisterValueType("csv-kelvin", kelvinType{})
+
+	schema := []ColumnSchema{
+		{Name: "city", Type: STRING},
+		{Name: "temp", Type: FLOAT, Meta: ColumnMeta{ValueType: "csv-kelvin"}},
+	}
+
+	filename := writeCsvTestFile(t, "city,temp\nLeón,280.5K\nOslo,\n")
+	df, err := NewDataFrameFromCsvFile(filename, CsvOptions{Schema: schema})
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	defer df.Close()
+
+	values, _ := df.Column("temp")
+	name, ok := ValueTypeName(values[0])
+	as.True(ok, "the csv value must be of the custom type")
+	as.Equal("csv-kelvin", name, "the type name is wrong")
+	as.Equal("280.5K", values[0].String(), "the value is wrong")
+	as.True(values[1].IsNull(), "the empty values are null")
+
+	b, err := NewBuilderFromSchema(schema)
+	if err != nil {
+		as.FailNow("error creating the builder", "error: %s", err.Error())
+	}
+
+	as.Nil(b.AppendCsv(strings.NewReader("temp\n3K\n"), ','), "there is an error in the function")
+	built, _ := b.Build()
+	values, _ = built.Column("temp")
+	_, ok = ValueTypeName(values[0])
+	as.True(ok, "the builder value must be of the custom type")
+
+	err = b.AppendCsv(strings.NewReader("temp\n3\n"), ',')
+	as.Equal("in line 2, column temp: invalid kelvin", err.Error(),
+		"the error message isn't match")
+
+	// errors.
+	_, err = NewBuilderFromSchema([]ColumnSchema{
+		{Name: "temp", Type: INT, Meta: ColumnMeta{ValueType: "csv-kelvin"}},
+	})
+	as.Equal("in column temp: the type csv-kelvin is float, not int", err.Error(),
+		"the error message isn't match")
+
+	_, err = NewBuilderFromSchema([]ColumnSchema{
+		{Name: "temp", Type: FLOAT, Meta: ColumnMeta{ValueType: "celsius"}},
+	})
+	as.Equal("in column temp: the type celsius isn't registered", err.Error(),
+		"the error message isn't match")
+}
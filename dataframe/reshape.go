@@ -0,0 +1,367 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// distinctValues stores the distinct values of a column.
+type distinctValues struct {
+	values []Value
+	index  map[string]int
+	// codes contains the position of the category values, that are grouped by their code
+	// without making the key of the value.
+	codes map[*categoryType]int
+}
+
+// newDistinctValues creates an empty distinctValues.
+func newDistinctValues() distinctValues {
+	return distinctValues{[]Value{}, map[string]int{}, map[*categoryType]int{}}
+}
+
+// add adds v to the distinct values, if it doesn't exist, and returns its position.
+func (d *distinctValues) add(v Value) int {
+	if c, ok := v.value.(*categoryType); ok {
+		pos, exists := d.codes[c]
+		if !exists {
+			pos = d.addKey(v)
+			d.codes[c] = pos
+		}
+
+		return pos
+	}
+
+	return d.addKey(v)
+}
+
+// addKey adds v to the distinct values using the key of the value, and returns its position.
+func (d *distinctValues) addKey(v Value) int {
+	key := valuesKey([]Value{v})
+	pos, exists := d.index[key]
+	if !exists {
+		pos = len(d.values)
+		d.index[key] = pos
+		d.values = append(d.values, v)
+	}
+
+	return pos
+}
+
+// sorted returns the positions of the values sorted in ascending order.
+func (d *distinctValues) sorted(ctype columnType) []int {
+	f := columnComparer(ctype)
+	positions := make([]int, len(d.values))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		comp, _ := f(d.values[positions[i]], d.values[positions[j]])
+		return comp == LESS
+	})
+
+	return positions
+}
+
+// pivotTable struct stores the aggregators of each pair of index and column values.
+type pivotTable struct {
+	index   distinctValues
+	columns distinctValues
+	cells   map[[2]int]Aggregator
+}
+
+// makePivotTable groups the rows of df by the values of the index and columns columns, and
+// calculates the aggregator, made with agg, in each group. The rows with null values in the
+// index or columns columns are ignored.
+func (df *DataFrame) makePivotTable(
+	index, columns, values string, agg func(colName string) Aggregator,
+) (*pivotTable, error) {
+	for _, colName := range []string{index, columns, values} {
+		if _, exists := df.getColumnByName(colName); !exists {
+			return nil, fmt.Errorf("column %s not found", colName)
+		}
+	}
+
+	// it uses a copy of the DataFrame, so the rows can't be reordered while they are grouped.
+	tmp := newDataFrameFromData(df.columns, df.snapshot())
+	proto := agg(values)
+	if err := proto.Init(tmp); err != nil {
+		return nil, err
+	}
+
+	table := pivotTable{
+		newDistinctValues(),
+		newDistinctValues(),
+		map[[2]int]Aggregator{},
+	}
+
+	iterator := tmp.Iterator()
+	for row, cont := iterator.Next(); cont; row, cont = iterator.Next() {
+		ivalue, _ := row.Cell(index)
+		cvalue, _ := row.Cell(columns)
+		if ivalue.IsNull() || cvalue.IsNull() {
+			continue
+		}
+
+		cell := [2]int{table.index.add(ivalue), table.columns.add(cvalue)}
+		a, exists := table.cells[cell]
+		if !exists {
+			a = proto.New()
+			a.Init(tmp)
+			table.cells[cell] = a
+		}
+
+		if err := a.Step(row); err != nil {
+			return nil, err
+		}
+	}
+
+	return &table, nil
+}
+
+// addGeneratedColumn adds a column, generated by the reshape functions, to the columns
+// array. It returns an error if the column name already exists.
+func addGeneratedColumn(
+	columns []column, cIndexByName map[string]int, name string, ctype columnType,
+) ([]column, error) {
+	if _, exists := cIndexByName[name]; exists {
+		return nil, fmt.Errorf("the column %s is duplicated", name)
+	}
+
+	cIndexByName[name] = len(columns)
+	return append(columns, column{name: name, ctype: ctype, index: len(columns), basicType: true}), nil
+}
+
+/*
+Pivot returns a new DataFrame, in wide format, with a row for each distinct value of the index
+column and a column for each distinct value of the columns column. The cells contain the
+result of the aggregator, made with agg, calculated with the values column of the rows that
+have the index and columns values. The cells without rows are null.
+
+The first column of the new DataFrame is the index column, and the generated columns are named
+with the values of the columns column as string. The rows and the generated columns are in
+ascending order. The rows with null values in the index or columns columns are ignored.
+
+Example:
+	// sales by country and year
+	df, err := df.Pivot("country", "year", "amount", NewSumAggregator)
+*/
+func (df *DataFrame) Pivot(
+	index, columns, values string, agg func(colName string) Aggregator,
+) (*DataFrame, error) {
+	table, err := df.makePivotTable(index, columns, values, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	icol, _ := df.getColumnByName(index)
+	ccol, _ := df.getColumnByName(columns)
+	vcol, _ := df.getColumnByName(values)
+	rows := table.index.sorted(icol.ctype)
+	cols := table.columns.sorted(ccol.ctype)
+
+	newColumns := []column{{name: index, ctype: icol.ctype, basicType: true, meta: icol.meta}}
+	cIndexByName := map[string]int{index: 0}
+
+	for _, c := range cols {
+		// the column type is the type of the aggregator results.
+		ctype := vcol.ctype
+		for r := range rows {
+			if a, exists := table.cells[[2]int{r, c}]; exists {
+				if r := a.Result(); !r.IsNull() {
+					ctype = r.Kind()
+					break
+				}
+			}
+		}
+
+		name := table.columns.values[c].String()
+		newColumns, err = addGeneratedColumn(newColumns, cIndexByName, name, ctype)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := make([]map[string]Value, len(rows))
+	for i, r := range rows {
+		row := map[string]Value{index: table.index.values[r]}
+		for j, c := range cols {
+			var value Value
+			if a, exists := table.cells[[2]int{r, c}]; exists {
+				value = a.Result()
+			}
+
+			row[newColumns[j+1].name] = value
+		}
+
+		data[i] = row
+	}
+
+	return newDataFrameFromData(newColumns, data), nil
+}
+
+/*
+Melt returns a new DataFrame, in long format, with the idVars columns and two new columns:
+"variable", type string, with the name of a valueVars column, and "value", with the value of
+this column. Each row of the DataFrame generates a row for each valueVars column. If valueVars
+is empty, it uses all columns that aren't in idVars. All valueVars columns must have the same
+type.
+
+Example:
+	// columns: country, 2019, 2020 => country, variable, value
+	df, err := df.Melt([]string{"country"}, []string{"2019", "2020"})
+*/
+func (df *DataFrame) Melt(idVars, valueVars []string) (*DataFrame, error) {
+	newColumns := []column{}
+	cIndexByName := map[string]int{}
+	isID := map[string]bool{}
+	var err error
+
+	for _, name := range idVars {
+		col, exists := df.getColumnByName(name)
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", name)
+		}
+
+		isID[name] = true
+		newColumns, err = addGeneratedColumn(newColumns, cIndexByName, name, col.ctype)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(valueVars) == 0 {
+		for _, col := range df.columns {
+			if !isID[col.name] {
+				valueVars = append(valueVars, col.name)
+			}
+		}
+	}
+
+	if len(valueVars) == 0 {
+		return nil, fmt.Errorf("there aren't columns to melt")
+	}
+
+	var ctype columnType
+	for i, name := range valueVars {
+		col, exists := df.getColumnByName(name)
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", name)
+		}
+
+		if i > 0 && col.ctype != ctype {
+			return nil, fmt.Errorf("the columns to melt must have the same type")
+		}
+
+		ctype = col.ctype
+	}
+
+	newColumns, err = addGeneratedColumn(newColumns, cIndexByName, "variable", STRING)
+	if err != nil {
+		return nil, err
+	}
+
+	newColumns, err = addGeneratedColumn(newColumns, cIndexByName, "value", ctype)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []map[string]Value{}
+	for _, row := range df.snapshot() {
+		for _, name := range valueVars {
+			newRow := map[string]Value{}
+			for _, id := range idVars {
+				newRow[id] = row[id]
+			}
+
+			newRow["variable"] = newStringValue(name)
+			newRow["value"] = row[name]
+			data = append(data, newRow)
+		}
+	}
+
+	return newDataFrameFromData(newColumns, data), nil
+}
+
+/*
+Crosstab returns a new DataFrame with the frequency table of the rowCol and colCol columns:
+a row for each distinct value of rowCol, a column for each distinct value of colCol, and in
+the cells the number of rows that have both values.
+
+The first column of the new DataFrame, named as rowCol, has type string and contains the rowCol
+values as string. The generated columns, type int, are named with the colCol values as string.
+Whether margins is true, it adds the "All" column and the "All" row, with the totals of each
+row and column. The rows with null values are ignored.
+*/
+func (df *DataFrame) Crosstab(rowCol, colCol string, margins bool) (*DataFrame, error) {
+	count := func(string) Aggregator {
+		return NewCountAggregator("")
+	}
+
+	table, err := df.makePivotTable(rowCol, colCol, rowCol, count)
+	if err != nil {
+		return nil, err
+	}
+
+	rcol, _ := df.getColumnByName(rowCol)
+	ccol, _ := df.getColumnByName(colCol)
+	rows := table.index.sorted(rcol.ctype)
+	cols := table.columns.sorted(ccol.ctype)
+
+	newColumns := []column{{name: rowCol, ctype: STRING, basicType: true}}
+	cIndexByName := map[string]int{rowCol: 0}
+
+	for _, c := range cols {
+		name := table.columns.values[c].String()
+		newColumns, err = addGeneratedColumn(newColumns, cIndexByName, name, INT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if margins {
+		newColumns, err = addGeneratedColumn(newColumns, cIndexByName, "All", INT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := []map[string]Value{}
+	totals := make([]int64, len(cols)+1)
+
+	for _, r := range rows {
+		row := map[string]Value{rowCol: newStringValue(table.index.values[r].String())}
+		var total int64
+
+		for j, c := range cols {
+			var n int64
+			if a, exists := table.cells[[2]int{r, c}]; exists {
+				result := a.Result()
+				n, _ = result.Int64()
+			}
+
+			row[newColumns[j+1].name] = newIntValue(n)
+			total += n
+			totals[j] += n
+		}
+
+		if margins {
+			row["All"] = newIntValue(total)
+			totals[len(cols)] += total
+		}
+
+		data = append(data, row)
+	}
+
+	if margins {
+		row := map[string]Value{rowCol: newStringValue("All")}
+		for j := range cols {
+			row[newColumns[j+1].name] = newIntValue(totals[j])
+		}
+
+		row["All"] = newIntValue(totals[len(cols)])
+		data = append(data, row)
+	}
+
+	return newDataFrameFromData(newColumns, data), nil
+}
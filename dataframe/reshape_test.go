@@ -0,0 +1,161 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type salesData struct {
+	Country string  `colName:"country"`
+	Year    int     `colName:"year"`
+	Amount  float64 `colName:"amount"`
+}
+
+// makeSalesDataFrame makes the DataFrame used in the reshape tests.
+func makeSalesDataFrame(t *testing.T) *DataFrame {
+	data := []salesData{
+		{"FR", 2020, 10},
+		{"ES", 2019, 1},
+		{"ES", 2020, 2},
+		{"ES", 2020, 3},
+		{"FR", 2021, 4},
+	}
+
+	return makeDataFrame(data, t)
+}
+
+// dataFrameRows returns the DataFrame rows as string arrays.
+func dataFrameRows(df *DataFrame) [][]string {
+	rows := [][]string{}
+	iterator := df.Iterator()
+	for row, cont := iterator.Next(); cont; row, cont = iterator.Next() {
+		values := []string{}
+		for _, name := range df.Headers() {
+			v, _ := row.Cell(name)
+			values = append(values, v.String())
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
+func Test_DataFrame_Pivot(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeSalesDataFrame(t); df == nil {
+		return
+	}
+
+	pivot, err := df.Pivot("country", "year", "amount", NewSumAggregator)
+	as.Nil(err, "there is an error in the pivot")
+	as.Equal([]string{"country", "2019", "2020", "2021"}, pivot.Headers(),
+		"the headers are wrong")
+	as.Equal([][]string{
+		{"ES", "1", "5", ""},
+		{"FR", "", "10", "4"},
+	}, dataFrameRows(pivot), "the rows are wrong")
+
+	// the type of the generated columns is the type of the results.
+	pivot, err = df.Pivot("year", "country", "amount", func(string) Aggregator {
+		return NewCountAggregator("")
+	})
+	as.Nil(err, "there is an error in the pivot")
+	as.Equal(INT, pivot.columns[1].ctype, "the column type is wrong")
+	as.Equal(INT, pivot.columns[0].ctype, "the index column type is wrong")
+	as.Equal([][]string{
+		{"2019", "1", ""},
+		{"2020", "2", "1"},
+		{"2021", "", "1"},
+	}, dataFrameRows(pivot), "the rows are wrong")
+
+	// errors
+	_, err = df.Pivot("country", "year", "x", NewSumAggregator)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+
+	_, err = df.Pivot("year", "country", "country", NewSumAggregator)
+	as.Equal("Sum operation is invalid in column type string", err.Error(),
+		"the error message doesn't match")
+
+	dup := makeDataFrame([]struct {
+		A string `colName:"a"`
+		B string `colName:"b"`
+		C int    `colName:"c"`
+	}{{"x", "a", 1}}, t)
+	_, err = dup.Pivot("a", "b", "c", NewSumAggregator)
+	as.Equal("the column a is duplicated", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Melt(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	data := []struct {
+		Country string `colName:"country"`
+		Y2019   int    `colName:"2019"`
+		Y2020   int    `colName:"2020"`
+	}{
+		{"ES", 1, 2},
+		{"FR", 3, 4},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	melted, err := df.Melt([]string{"country"}, nil)
+	as.Nil(err, "there is an error in the melt")
+	as.Equal([]string{"country", "variable", "value"}, melted.Headers(),
+		"the headers are wrong")
+	as.Equal([][]string{
+		{"ES", "2019", "1"},
+		{"ES", "2020", "2"},
+		{"FR", "2019", "3"},
+		{"FR", "2020", "4"},
+	}, dataFrameRows(melted), "the rows are wrong")
+
+	melted, _ = df.Melt(nil, []string{"2020"})
+	as.Equal([][]string{{"2020", "2"}, {"2020", "4"}}, dataFrameRows(melted),
+		"the rows are wrong")
+
+	// errors
+	_, err = df.Melt(nil, nil)
+	as.Equal("the columns to melt must have the same type", err.Error(),
+		"the error message doesn't match")
+
+	_, err = df.Melt([]string{"x"}, nil)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+
+	_, err = df.Melt([]string{"country", "2019", "2020"}, nil)
+	as.Equal("there aren't columns to melt", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Crosstab(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeSalesDataFrame(t); df == nil {
+		return
+	}
+
+	table, err := df.Crosstab("country", "year", false)
+	as.Nil(err, "there is an error in the crosstab")
+	as.Equal([][]string{
+		{"ES", "1", "2", "0"},
+		{"FR", "0", "1", "1"},
+	}, dataFrameRows(table), "the rows are wrong")
+
+	table, err = df.Crosstab("year", "country", true)
+	as.Nil(err, "there is an error in the crosstab")
+	as.Equal([]string{"year", "ES", "FR", "All"}, table.Headers(), "the headers are wrong")
+	as.Equal([][]string{
+		{"2019", "1", "0", "1"},
+		{"2020", "2", "1", "3"},
+		{"2021", "0", "1", "1"},
+		{"All", "3", "2", "5"},
+	}, dataFrameRows(table), "the rows are wrong")
+
+	_, err = df.Crosstab("country", "x", false)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+}
@@ -10,20 +10,27 @@ type Row struct {
 	df *DataFrame
 	// Row index in DataFrame.
 	index int
+	// flag indicating the DataFrame read lock is already held.
+	locked bool
 }
 
 // newRow creates a new Row of the df DataFrame.
 // If index param is more great or equal than DataFrame length, it return an error.
 func newRow(df *DataFrame, index int) (Row, error) {
 	if df.handler.Len() <= index {
-		return Row{nil, 0}, fmt.Errorf("row %d out of range", index)
+		return Row{nil, 0, false}, fmt.Errorf("row %d out of range", index)
 	}
 
-	return Row{df, index}, nil
+	return Row{df, index, false}, nil
 }
 
 // Cell returns the value inside of the Row of the colname column.
 // If the column does not exists, then returns an error.
 func (r *Row) Cell(colname string) (Value, error) {
+	if !r.locked {
+		r.df.lock.RLock()
+		defer r.df.lock.RUnlock()
+	}
+
 	return r.df.handler.Get(r.index, colname)
 }
@@ -0,0 +1,489 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// corrMethod is the method used to calculate the correlation between two columns.
+type corrMethod int8
+
+// The valid correlation methods.
+const (
+	CORR_PEARSON  corrMethod = 0 // linear correlation.
+	CORR_SPEARMAN corrMethod = 1 // Pearson correlation of the ranks of the values.
+	CORR_KENDALL  corrMethod = 2 // Kendall tau-b rank correlation.
+)
+
+// numericColumn contains the values of a numeric column as floats. The null values and the
+// NaN floats aren't valid.
+type numericColumn struct {
+	name   string
+	values []float64
+	valid  []bool
+}
+
+// numericFloat converts the v value of a numeric column to float. Returns false if the value
+// is null or NaN.
+func numericFloat(v Value) (float64, bool) {
+	switch raw := v.rawValue().(type) {
+	case int64:
+		return float64(raw), true
+	case uint64:
+		return float64(raw), true
+	case float64:
+		return raw, !math.IsNaN(raw)
+	case Decimal:
+		return raw.Float64(), true
+	default:
+		return 0, false
+	}
+}
+
+// numericColumns returns the values of the names columns in the data rows as floats. If names
+// is empty, it uses all int, uint, float and decimal columns. Returns an error if a column
+// doesn't exist, it is duplicated or it isn't numeric.
+func (df *DataFrame) numericColumns(
+	data []map[string]Value, names []string,
+) ([]numericColumn, error) {
+	columns := []column{}
+	if len(names) == 0 {
+		for _, col := range df.columns {
+			if isNumberType(col.ctype) || col.ctype == DECIMAL {
+				columns = append(columns, col)
+			}
+		}
+	} else {
+		var err error
+		if columns, err = df.selectColumns(names); err != nil {
+			return nil, err
+		}
+	}
+
+	numeric := []numericColumn{}
+
+	for _, col := range columns {
+		if !isNumberType(col.ctype) && col.ctype != DECIMAL {
+			return nil, fmt.Errorf("the column %s isn't numeric", col.name)
+		}
+
+		nc := numericColumn{col.name, make([]float64, len(data)), make([]bool, len(data))}
+		for i, row := range data {
+			nc.values[i], nc.valid[i] = numericFloat(row[col.name])
+		}
+
+		numeric = append(numeric, nc)
+	}
+
+	return numeric, nil
+}
+
+// pairValues returns the values of the rows where a and b columns have valid values.
+func pairValues(a, b *numericColumn) ([]float64, []float64) {
+	x, y := []float64{}, []float64{}
+	for i := range a.values {
+		if a.valid[i] && b.valid[i] {
+			x = append(x, a.values[i])
+			y = append(y, b.values[i])
+		}
+	}
+
+	return x, y
+}
+
+// mean returns the arithmetic mean of the values.
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// covariance returns the sample covariance of x and y. Returns false if there are less than
+// 2 values.
+func covariance(x, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+
+	mx, my := mean(x), mean(y)
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - mx) * (y[i] - my)
+	}
+
+	return sum / float64(len(x)-1), true
+}
+
+// pearson returns the Pearson correlation of x and y. Returns false if there are less than 2
+// values or some of them is constant.
+func pearson(x, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+
+	mx, my := mean(x), mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+
+	if sxx == 0 || syy == 0 {
+		return 0, false
+	}
+
+	// the rounding errors can place the result out of [-1, 1].
+	return math.Max(-1, math.Min(1, sxy/math.Sqrt(sxx*syy))), true
+}
+
+// ranks returns the rank of each value, starting from 1. The equal values have the average of
+// their ranks.
+func ranks(values []float64) []float64 {
+	positions := make([]int, len(values))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		return values[positions[i]] < values[positions[j]]
+	})
+
+	result := make([]float64, len(values))
+	for i := 0; i < len(positions); {
+		j := i
+		for j < len(positions) && values[positions[j]] == values[positions[i]] {
+			j++
+		}
+
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			result[positions[k]] = rank
+		}
+
+		i = j
+	}
+
+	return result
+}
+
+// kendall returns the Kendall tau-b correlation of x and y, that takes the ties into account.
+// Returns false if there are less than 2 values or some of them is constant.
+func kendall(x, y []float64) (float64, bool) {
+	var concordant, discordant, tiesX, tiesY float64
+
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			dx, dy := x[i]-x[j], y[i]-y[j]
+
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	denominator := math.Sqrt((concordant + discordant + tiesX) * (concordant + discordant + tiesY))
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return (concordant - discordant) / denominator, true
+}
+
+// correlation returns the correlation of x and y using the method. Returns false if the
+// correlation can't be calculated.
+func correlation(x, y []float64, method corrMethod) (float64, bool) {
+	switch method {
+	case CORR_SPEARMAN:
+		return pearson(ranks(x), ranks(y))
+	case CORR_KENDALL:
+		return kendall(x, y)
+	default:
+		return pearson(x, y)
+	}
+}
+
+// checkCorrMethod checks if the correlation method is valid.
+func checkCorrMethod(method corrMethod) error {
+	if method != CORR_PEARSON && method != CORR_SPEARMAN && method != CORR_KENDALL {
+		return fmt.Errorf("invalid correlation method")
+	}
+
+	return nil
+}
+
+// pairMatrix returns a square DataFrame with the result of the f function for each pair of
+// numeric columns. The first column, named "column", contains the column names.
+func (df *DataFrame) pairMatrix(f func(x, y []float64) (float64, bool)) (*DataFrame, error) {
+	numeric, err := df.numericColumns(df.snapshot(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []column{{name: "column", ctype: STRING, basicType: true}}
+	cIndexByName := map[string]int{"column": 0}
+
+	for _, nc := range numeric {
+		if columns, err = addGeneratedColumn(columns, cIndexByName, nc.name, FLOAT); err != nil {
+			return nil, err
+		}
+	}
+
+	data := make([]map[string]Value, len(numeric))
+	for i := range numeric {
+		data[i] = map[string]Value{"column": newStringValue(numeric[i].name)}
+	}
+
+	for i := range numeric {
+		for j := i; j < len(numeric); j++ {
+			value := Value{}
+			if result, ok := f(pairValues(&numeric[i], &numeric[j])); ok {
+				value = newFloatValue(result)
+			}
+
+			data[i][numeric[j].name] = value
+			data[j][numeric[i].name] = value
+		}
+	}
+
+	return newDataFrameFromData(columns, data), nil
+}
+
+// Corr returns a square DataFrame with the correlation, calculated with the method, of each
+// pair of numeric columns (int, uint and float). The first column, type string and named
+// "column", contains the column names, and the other columns, type float, are named as the
+// numeric columns.
+//
+// Each correlation uses the rows where both columns have values: the null values and the NaN
+// floats are ignored. The correlations that can't be calculated, because there are less than
+// 2 rows or a column is constant, are null. The Kendall method compares all pairs of rows, so
+// it is slow in big DataFrames.
+//
+// Example:
+//	corr, err := df.Corr(CORR_SPEARMAN)
+func (df *DataFrame) Corr(method corrMethod) (*DataFrame, error) {
+	if err := checkCorrMethod(method); err != nil {
+		return nil, err
+	}
+
+	return df.pairMatrix(func(x, y []float64) (float64, bool) {
+		return correlation(x, y, method)
+	})
+}
+
+// Cov returns a square DataFrame with the sample covariance of each pair of numeric columns,
+// like Corr. The covariances that can't be calculated, because there are less than 2 rows,
+// are null.
+func (df *DataFrame) Cov() (*DataFrame, error) {
+	return df.pairMatrix(covariance)
+}
+
+// CorrWith returns the correlation of the colA and colB columns, calculated with the method.
+// The rows with null values or NaN floats are ignored. Returns an error if the columns don't
+// exist, they aren't numeric or the correlation can't be calculated.
+func (df *DataFrame) CorrWith(colA, colB string, method corrMethod) (float64, error) {
+	if err := checkCorrMethod(method); err != nil {
+		return 0, err
+	}
+
+	// the correlation of a column with itself is valid, so the column is selected once.
+	names := []string{colA, colB}
+	if colA == colB {
+		names = names[:1]
+	}
+
+	numeric, err := df.numericColumns(df.snapshot(), names)
+	if err != nil {
+		return 0, err
+	}
+
+	x, y := pairValues(&numeric[0], &numeric[len(numeric)-1])
+	result, ok := correlation(x, y, method)
+	if !ok {
+		return 0, fmt.Errorf("the correlation of the columns %s and %s can't be calculated",
+			colA, colB)
+	}
+
+	return result, nil
+}
+
+// LinearRegressionResult struct contains the result of a linear regression.
+type LinearRegressionResult struct {
+	// Intercept is the constant term of the regression.
+	Intercept float64
+	// Coefficients contains the coefficient of each x column, in the same order.
+	Coefficients []float64
+	// R2 is the coefficient of determination. It is NaN when the y column is constant.
+	R2 float64
+	// DataFrame contains the rows of the DataFrame and the "residual" column, type float, with
+	// the residual of each row. The residuals of the rows not used in the regression are null.
+	DataFrame *DataFrame
+}
+
+// solveLinearSystem solves the a·x = b linear system using the Gaussian elimination with
+// partial pivoting. a and b are modified. Returns false if the system is singular.
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	scale := 0.0
+	for i := range a {
+		scale = math.Max(scale, math.Abs(a[i][i]))
+	}
+
+	for k := 0; k < n; k++ {
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[pivot][k]) {
+				pivot = i
+			}
+		}
+
+		if math.Abs(a[pivot][k]) <= scale*1e-12 {
+			return nil, false
+		}
+
+		a[k], a[pivot] = a[pivot], a[k]
+		b[k], b[pivot] = b[pivot], b[k]
+
+		for i := k + 1; i < n; i++ {
+			factor := a[i][k] / a[k][k]
+			for j := k; j < n; j++ {
+				a[i][j] -= factor * a[k][j]
+			}
+
+			b[i] -= factor * b[k]
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i][j] * x[j]
+		}
+
+		x[i] = sum / a[i][i]
+	}
+
+	return x, true
+}
+
+/*
+LinearRegression fits the y column with the xs columns using ordinary least squares, and
+returns the intercept, the coefficients, the R² and a new DataFrame with the residuals in the
+"residual" column. The columns must be numeric (int, uint or float), and the rows with null
+values or NaN floats in some of them aren't used.
+
+Returns an error if there aren't x columns, there aren't enough rows, the xs columns are
+collinear or the DataFrame already has the "residual" column.
+
+Example:
+	result, err := df.LinearRegression("price", "size", "rooms")
+	price := result.Intercept + result.Coefficients[0]*size + result.Coefficients[1]*rooms
+*/
+func (df *DataFrame) LinearRegression(y string, xs ...string) (*LinearRegressionResult, error) {
+	if len(xs) == 0 {
+		return nil, fmt.Errorf("the regression needs at least one x column")
+	}
+
+	data := df.snapshot()
+	numeric, err := df.numericColumns(data, append([]string{y}, xs...))
+	if err != nil {
+		return nil, err
+	}
+
+	// rows used in the regression.
+	rows := []int{}
+	for i := range numeric[0].values {
+		valid := true
+		for _, nc := range numeric {
+			valid = valid && nc.valid[i]
+		}
+
+		if valid {
+			rows = append(rows, i)
+		}
+	}
+
+	p := len(xs)
+	if len(rows) <= p {
+		return nil, fmt.Errorf("there aren't enough rows to fit the regression")
+	}
+
+	// the values are centered, so the intercept isn't in the system.
+	means := make([]float64, p+1)
+	for j, nc := range numeric {
+		for _, i := range rows {
+			means[j] += nc.values[i]
+		}
+
+		means[j] /= float64(len(rows))
+	}
+
+	a := make([][]float64, p)
+	b := make([]float64, p)
+	for j := range a {
+		a[j] = make([]float64, p)
+	}
+
+	for _, i := range rows {
+		dy := numeric[0].values[i] - means[0]
+		for j := 0; j < p; j++ {
+			dj := numeric[j+1].values[i] - means[j+1]
+			b[j] += dj * dy
+
+			for k := 0; k < p; k++ {
+				a[j][k] += dj * (numeric[k+1].values[i] - means[k+1])
+			}
+		}
+	}
+
+	coefficients, ok := solveLinearSystem(a, b)
+	if !ok {
+		return nil, fmt.Errorf("the x columns are collinear")
+	}
+
+	result := LinearRegressionResult{Intercept: means[0], Coefficients: coefficients}
+	for j, c := range coefficients {
+		result.Intercept -= c * means[j+1]
+	}
+
+	residuals := make([]Value, len(numeric[0].values))
+	var ssRes, ssTot float64
+
+	for _, i := range rows {
+		predicted := result.Intercept
+		for j, c := range coefficients {
+			predicted += c * numeric[j+1].values[i]
+		}
+
+		residual := numeric[0].values[i] - predicted
+		residuals[i] = newFloatValue(residual)
+		ssRes += residual * residual
+		ssTot += (numeric[0].values[i] - means[0]) * (numeric[0].values[i] - means[0])
+	}
+
+	result.R2 = math.NaN()
+	if ssTot != 0 {
+		result.R2 = 1 - ssRes/ssTot
+	}
+
+	result.DataFrame, err = df.newDataFrameWithColumn(data, "residual", FLOAT, residuals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,205 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// makeStatsDataFrame returns a DataFrame with numeric columns to test the statistics.
+func makeStatsDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"x":    []int{1, 2, 3, 4, 5},
+		"y":    []float64{2, 4, 6, 8, 100},
+		"z":    []*uint{uintPtr(5), uintPtr(4), nil, uintPtr(2), uintPtr(1)},
+		"name": []string{"a", "b", "c", "d", "e"},
+	}, "x", "y", "z", "name")
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+// matrixValue returns the value of the colName column in the row of the rowName column of a
+// correlation or covariance matrix.
+func matrixValue(df *DataFrame, rowName, colName string) Value {
+	iterator := df.Iterator()
+	for row, cont := iterator.Next(); cont; row, cont = iterator.Next() {
+		name, _ := row.Cell("column")
+		if name.String() == rowName {
+			v, _ := row.Cell(colName)
+			return v
+		}
+	}
+
+	return Value{}
+}
+
+func Test_ranks_func(t *testing.T) {
+	as := assert.New(t)
+	as.Equal([]float64{2.5, 1, 2.5, 4}, ranks([]float64{3, 1, 3, 7}), "the ranks are wrong")
+	as.Equal([]float64{}, ranks([]float64{}), "the ranks are wrong")
+}
+
+func Test_DataFrame_Corr_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeStatsDataFrame(t)
+
+	corr, err := df.Corr(CORR_PEARSON)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"column", "x", "y", "z"}, corr.Headers(), "the headers are wrong")
+	as.Equal(3, corr.NumberRows(), "the number of rows is wrong")
+
+	v := matrixValue(corr, "x", "x")
+	f, _ := v.Float64()
+	as.Equal(1.0, f, "the correlation is wrong")
+
+	// the null values are ignored.
+	v = matrixValue(corr, "x", "z")
+	f, _ = v.Float64()
+	as.InDelta(-1.0, f, 1e-12, "the correlation is wrong")
+
+	v = matrixValue(corr, "y", "x")
+	f, _ = v.Float64()
+	as.InDelta(0.7433, f, 1e-4, "the correlation is wrong")
+	v = matrixValue(corr, "x", "y")
+	g, _ := v.Float64()
+	as.Equal(f, g, "the matrix must be symmetric")
+
+	// the ranks are monotonic.
+	corr, _ = df.Corr(CORR_SPEARMAN)
+	v = matrixValue(corr, "x", "y")
+	f, _ = v.Float64()
+	as.InDelta(1.0, f, 1e-12, "the correlation is wrong")
+
+	corr, _ = df.Corr(CORR_KENDALL)
+	v = matrixValue(corr, "y", "z")
+	f, _ = v.Float64()
+	as.InDelta(-1.0, f, 1e-12, "the correlation is wrong")
+
+	_, err = df.Corr(5)
+	as.Equal("invalid correlation method", err.Error(), "the error message doesn't match")
+
+	// constant column.
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"a": []float64{1, 1, 1}, "b": []float64{1, 2, 3},
+	})
+	corr, _ = df.Corr(CORR_PEARSON)
+	v = matrixValue(corr, "a", "b")
+	as.True(v.IsNull(), "the correlation must be null")
+}
+
+func Test_DataFrame_Cov_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeStatsDataFrame(t)
+
+	cov, err := df.Cov()
+	as.Nil(err, "there is an error in the function")
+
+	v := matrixValue(cov, "x", "x")
+	f, _ := v.Float64()
+	as.Equal(2.5, f, "the variance is wrong")
+
+	v = matrixValue(cov, "x", "z")
+	f, _ = v.Float64()
+	as.InDelta(-10.0/3, f, 1e-12, "the covariance is wrong")
+
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{"a": []float64{1}})
+	cov, _ = df.Cov()
+	v = matrixValue(cov, "a", "a")
+	as.True(v.IsNull(), "the covariance must be null")
+}
+
+func Test_DataFrame_CorrWith_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeStatsDataFrame(t)
+
+	corr, err := df.CorrWith("x", "z", CORR_SPEARMAN)
+	as.Nil(err, "there is an error in the function")
+	as.InDelta(-1.0, corr, 1e-12, "the correlation is wrong")
+
+	corr, err = df.CorrWith("z", "z", CORR_PEARSON)
+	as.Nil(err, "there is an error in the function")
+	as.InDelta(1.0, corr, 1e-12, "the correlation of a column with itself is wrong")
+
+	_, err = df.CorrWith("x", "name", CORR_PEARSON)
+	as.Equal("the column name isn't numeric", err.Error(), "the error message doesn't match")
+	_, err = df.CorrWith("x", "none", CORR_PEARSON)
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+	_, err = df.CorrWith("x", "z", 7)
+	as.Equal("invalid correlation method", err.Error(), "the error message doesn't match")
+
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"a": []float64{1, 1, 1}, "b": []float64{1, 2, math.NaN()},
+	})
+	_, err = df.CorrWith("a", "b", CORR_KENDALL)
+	as.Equal("the correlation of the columns a and b can't be calculated", err.Error(),
+		"the error message doesn't match")
+}
+
+func Test_DataFrame_LinearRegression_func(t *testing.T) {
+	as := assert.New(t)
+
+	// y = 1 + 2·a - 3·b, without noise.
+	a := []float64{1, 2, 3, 4, 5, 6}
+	b := []float64{2, 1, 4, 3, 6, 5}
+	y := []*float64{}
+	for i := range a {
+		v := 1 + 2*a[i] - 3*b[i]
+		y = append(y, &v)
+	}
+
+	y[5] = nil
+	df, _ := NewDataFrameFromColumns(map[string]interface{}{"a": a, "b": b, "y": y})
+
+	result, err := df.LinearRegression("y", "a", "b")
+	if err != nil {
+		as.FailNow("error in the regression", "error: %s", err.Error())
+	}
+
+	as.InDelta(1.0, result.Intercept, 1e-9, "the intercept is wrong")
+	as.InDelta(2.0, result.Coefficients[0], 1e-9, "the coefficient is wrong")
+	as.InDelta(-3.0, result.Coefficients[1], 1e-9, "the coefficient is wrong")
+	as.InDelta(1.0, result.R2, 1e-9, "the R2 is wrong")
+
+	residuals := result.DataFrame
+	as.Equal([]string{"a", "b", "y", "residual"}, residuals.Headers(), "the headers are wrong")
+	values, _ := residuals.ColumnRange("residual", 0, 6)
+	f, _ := values[0].Float64()
+	as.InDelta(0.0, f, 1e-9, "the residual is wrong")
+	as.True(values[5].IsNull(), "the residual of the row without y must be null")
+
+	// simple regression with noise.
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"x": []int{1, 2, 3, 4}, "y": []float64{1, 3, 2, 4},
+	})
+	result, _ = df.LinearRegression("y", "x")
+	as.InDelta(0.5, result.Intercept, 1e-12, "the intercept is wrong")
+	as.InDelta(0.8, result.Coefficients[0], 1e-12, "the coefficient is wrong")
+	as.InDelta(0.64, result.R2, 1e-12, "the R2 is wrong")
+
+	// errors.
+	_, err = df.LinearRegression("y")
+	as.Equal("the regression needs at least one x column", err.Error(),
+		"the error message doesn't match")
+
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"x": []int{1, 2, 3}, "w": []int{2, 4, 6}, "y": []float64{1, 3, 2},
+	})
+	_, err = df.LinearRegression("y", "x", "w")
+	as.Equal("the x columns are collinear", err.Error(), "the error message doesn't match")
+
+	_, err = df.LinearRegression("y", "x", "w", "x")
+	as.Equal("the column x is duplicated", err.Error(), "the error message doesn't match")
+	_, err = df.LinearRegression("x", "x")
+	as.Equal("the column x is duplicated", err.Error(), "the error message doesn't match")
+
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"x": []int{1, 2}, "w": []int{2, 5}, "y": []float64{1, 3},
+	})
+	_, err = df.LinearRegression("y", "x", "w")
+	as.Equal("there aren't enough rows to fit the regression", err.Error(),
+		"the error message doesn't match")
+}
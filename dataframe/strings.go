@@ -0,0 +1,359 @@
+package dataframe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// padSide indicates the side where the StrPad function adds the pad characters.
+type padSide int8
+
+// The valid sides of the pad.
+const (
+	PAD_LEFT  padSide = 0
+	PAD_RIGHT padSide = 1
+	PAD_BOTH  padSide = 2
+)
+
+// stringColumn returns a snapshot of the DataFrame rows and the values of the colName column.
+// The name param is the name of the function used in the error message. Returns an error if
+// the column doesn't exist or it isn't type string or category.
+func (df *DataFrame) stringColumn(colName, name string) ([]map[string]Value, []Value, error) {
+	if _, err := df.windowColumn(colName, name, STRING, CATEGORY); err != nil {
+		return nil, nil, err
+	}
+
+	data := df.snapshot()
+	return data, columnValues(data, colName), nil
+}
+
+// mapStrings returns a new DataFrame with the newColName column, type string, that contains
+// the result of the f function with the value of the colName column of each row. The null
+// values are null in the new column too.
+func (df *DataFrame) mapStrings(
+	colName, newColName, name string, f func(str string) string,
+) (*DataFrame, error) {
+	data, values, err := df.stringColumn(colName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Value, len(values))
+	for i, v := range values {
+		if str, err := v.Str(); err == nil {
+			results[i] = newStringValue(f(str))
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, STRING, results)
+}
+
+// matchStrings returns an array with a bool for each DataFrame row, with the result of the f
+// function with the value of the colName column. The null values are always false.
+func (df *DataFrame) matchStrings(colName, name string, f func(str string) bool) ([]bool, error) {
+	_, values, err := df.stringColumn(colName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	mask := make([]bool, len(values))
+	for i, v := range values {
+		if str, err := v.Str(); err == nil {
+			mask[i] = f(str)
+		}
+	}
+
+	return mask, nil
+}
+
+// StrUpper returns a new DataFrame with the newColName column, type string, that contains the
+// value of the colName column in upper case. The column must be type string or category.
+func (df *DataFrame) StrUpper(colName, newColName string) (*DataFrame, error) {
+	return df.mapStrings(colName, newColName, "StrUpper", strings.ToUpper)
+}
+
+// StrLower returns a new DataFrame with the newColName column, type string, that contains the
+// value of the colName column in lower case. The column must be type string or category.
+func (df *DataFrame) StrLower(colName, newColName string) (*DataFrame, error) {
+	return df.mapStrings(colName, newColName, "StrLower", strings.ToLower)
+}
+
+// StrTrim returns a new DataFrame with the newColName column, type string, that contains the
+// value of the colName column without the leading and trailing characters contained in
+// cutset. If cutset is empty, it removes the white spaces.
+func (df *DataFrame) StrTrim(colName, newColName, cutset string) (*DataFrame, error) {
+	return df.mapStrings(colName, newColName, "StrTrim", func(str string) string {
+		if cutset == "" {
+			return strings.TrimSpace(str)
+		}
+
+		return strings.Trim(str, cutset)
+	})
+}
+
+// StrContains returns an array with a bool for each DataFrame row, indicating if the value of
+// the colName column contains substr. The null values are false.
+func (df *DataFrame) StrContains(colName, substr string) ([]bool, error) {
+	return df.matchStrings(colName, "StrContains", func(str string) bool {
+		return strings.Contains(str, substr)
+	})
+}
+
+// StrHasPrefix returns an array with a bool for each DataFrame row, indicating if the value
+// of the colName column begins with prefix. The null values are false.
+func (df *DataFrame) StrHasPrefix(colName, prefix string) ([]bool, error) {
+	return df.matchStrings(colName, "StrHasPrefix", func(str string) bool {
+		return strings.HasPrefix(str, prefix)
+	})
+}
+
+// StrHasSuffix returns an array with a bool for each DataFrame row, indicating if the value
+// of the colName column ends with suffix. The null values are false.
+func (df *DataFrame) StrHasSuffix(colName, suffix string) ([]bool, error) {
+	return df.matchStrings(colName, "StrHasSuffix", func(str string) bool {
+		return strings.HasSuffix(str, suffix)
+	})
+}
+
+// StrMatch returns an array with a bool for each DataFrame row, indicating if the value of
+// the colName column contains a match of the pattern regular expression. The null values are
+// false. Returns an error if the pattern is invalid.
+func (df *DataFrame) StrMatch(colName, pattern string) ([]bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return df.matchStrings(colName, "StrMatch", re.MatchString)
+}
+
+/*
+StrExtract returns a new DataFrame with the newColName column, type string, that contains the
+text matched by the group of the pattern regular expression in the value of the colName
+column. The group 0 is the whole match. The value is null whether the value doesn't match the
+pattern or the group doesn't participate in the match. Returns an error if the pattern is
+invalid or it hasn't the group.
+
+Example:
+	// the domain of the email addresses.
+	df, err := df.StrExtract("email", "domain", `@([\w.-]+)$`, 1)
+*/
+func (df *DataFrame) StrExtract(
+	colName, newColName, pattern string, group int,
+) (*DataFrame, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if group < 0 || group > re.NumSubexp() {
+		return nil, fmt.Errorf("the regular expression %s hasn't the group %d", pattern, group)
+	}
+
+	data, values, err := df.stringColumn(colName, "StrExtract")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Value, len(values))
+	for i, v := range values {
+		str, err := v.Str()
+		if err != nil {
+			continue
+		}
+
+		if match := re.FindStringSubmatchIndex(str); match != nil && match[2*group] >= 0 {
+			results[i] = newStringValue(str[match[2*group]:match[2*group+1]])
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, STRING, results)
+}
+
+// StrReplace returns a new DataFrame with the newColName column, type string, that contains
+// the value of the colName column with the matches of the pattern regular expression replaced
+// by repl. Inside repl, $1 or ${name} are replaced by the text of the groups, like in the
+// regexp.ReplaceAllString function. Returns an error if the pattern is invalid.
+func (df *DataFrame) StrReplace(colName, newColName, pattern, repl string) (*DataFrame, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return df.mapStrings(colName, newColName, "StrReplace", func(str string) string {
+		return re.ReplaceAllString(str, repl)
+	})
+}
+
+/*
+StrSplit returns a new DataFrame with the newColNames columns, type string, that contain the
+parts of the value of the colName column split by sep. The value is split in as many parts as
+columns, so the last column contains the rest of the value. The columns without part are null.
+
+Example:
+	// "Doe, John" => "Doe", "John"
+	df, err := df.StrSplit("name", ", ", "last_name", "first_name")
+*/
+func (df *DataFrame) StrSplit(colName, sep string, newColNames ...string) (*DataFrame, error) {
+	if len(newColNames) == 0 {
+		return nil, fmt.Errorf("there aren't columns to store the parts")
+	}
+
+	if sep == "" {
+		return nil, fmt.Errorf("the separator can't be empty")
+	}
+
+	data, values, err := df.stringColumn(colName, "StrSplit")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]Value, len(newColNames))
+	for i := range results {
+		results[i] = make([]Value, len(values))
+	}
+
+	for i, v := range values {
+		str, err := v.Str()
+		if err != nil {
+			continue
+		}
+
+		for j, part := range strings.SplitN(str, sep, len(newColNames)) {
+			results[j][i] = newStringValue(part)
+		}
+	}
+
+	for i, name := range newColNames {
+		if df, err = df.newDataFrameWithColumn(data, name, STRING, results[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return df, nil
+}
+
+// StrLen returns a new DataFrame with the newColName column, type int, that contains the
+// number of characters of the value of the colName column. The null values are null in the
+// new column too.
+func (df *DataFrame) StrLen(colName, newColName string) (*DataFrame, error) {
+	data, values, err := df.stringColumn(colName, "StrLen")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Value, len(values))
+	for i, v := range values {
+		if str, err := v.Str(); err == nil {
+			results[i] = newIntValue(int64(utf8.RuneCountInString(str)))
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, INT, results)
+}
+
+// StrSubstring returns a new DataFrame with the newColName column, type string, that contains
+// length characters of the value of the colName column, beginning in the start character. If
+// length is negative, the substring ends at the end of the value. The substring is shorter, or
+// empty, when the value hasn't enough characters. Returns an error if start is negative.
+func (df *DataFrame) StrSubstring(
+	colName, newColName string, start, length int,
+) (*DataFrame, error) {
+	if start < 0 {
+		return nil, fmt.Errorf("the start index must be non-negative")
+	}
+
+	return df.mapStrings(colName, newColName, "StrSubstring", func(str string) string {
+		runes := []rune(str)
+		if start >= len(runes) {
+			return ""
+		}
+
+		if length >= 0 && length < len(runes)-start {
+			return string(runes[start : start+length])
+		}
+
+		return string(runes[start:])
+	})
+}
+
+// StrPad returns a new DataFrame with the newColName column, type string, that contains the
+// value of the colName column padded with the pad character until it has width characters.
+// The side param indicates where the characters are added: PAD_LEFT, PAD_RIGHT or PAD_BOTH.
+// With PAD_BOTH, the extra character is added on the right. The longer values aren't modified.
+// Returns an error if pad isn't a single character.
+func (df *DataFrame) StrPad(
+	colName, newColName string, width int, pad string, side padSide,
+) (*DataFrame, error) {
+	if utf8.RuneCountInString(pad) != 1 {
+		return nil, fmt.Errorf("the pad must be a single character")
+	}
+
+	if side != PAD_LEFT && side != PAD_RIGHT && side != PAD_BOTH {
+		return nil, fmt.Errorf("invalid pad side")
+	}
+
+	return df.mapStrings(colName, newColName, "StrPad", func(str string) string {
+		n := width - utf8.RuneCountInString(str)
+		if n <= 0 {
+			return str
+		}
+
+		switch side {
+		case PAD_LEFT:
+			return strings.Repeat(pad, n) + str
+		case PAD_RIGHT:
+			return str + strings.Repeat(pad, n)
+		default:
+			return strings.Repeat(pad, n/2) + str + strings.Repeat(pad, n-n/2)
+		}
+	})
+}
+
+/*
+StrConcat returns a new DataFrame with the newColName column, type string, that contains the
+values of the cols columns joined with sep. The columns can be of any type, and their values
+are converted with the String function. The value is null whether some of the values is null.
+A column can be joined several times.
+
+Example:
+	df, err := df.StrConcat("address", ", ", "street", "city", "country")
+*/
+func (df *DataFrame) StrConcat(newColName, sep string, cols ...string) (*DataFrame, error) {
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("there aren't columns to concatenate")
+	}
+
+	// the columns aren't selected with selectColumns, because they can be repeated.
+	columns := []column{}
+	for _, name := range cols {
+		col, exists := df.getColumnByName(name)
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", name)
+		}
+
+		columns = append(columns, *col)
+	}
+
+	data := df.snapshot()
+	results := make([]Value, len(data))
+	parts := make([]string, len(columns))
+
+rows:
+	for i, row := range data {
+		for j, col := range columns {
+			v := row[col.name]
+			if v.IsNull() {
+				continue rows
+			}
+
+			parts[j] = v.String()
+		}
+
+		results[i] = newStringValue(strings.Join(parts, sep))
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, STRING, results)
+}
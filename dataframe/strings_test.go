@@ -0,0 +1,229 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// makeStringsDataFrame returns a DataFrame with the name, email and age columns.
+func makeStringsDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"name": []*string{strPtr("  Doe, John "), strPtr("Ñúñez, Ana"), nil, strPtr("smith")},
+		"email": []*string{
+			strPtr("john@doe.com"), strPtr("ana@mail.es"), strPtr("bob@mail.es"), nil,
+		},
+		"age": []int{30, 25, 41, 19},
+	}, "name", "email", "age")
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+func Test_DataFrame_Str_Transforms(t *testing.T) {
+	as := assert.New(t)
+	df := makeStringsDataFrame(t)
+
+	upper, err := df.StrUpper("name", "upper")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(STRING, upper.Schema()[3].Type, "the column type is wrong")
+	as.Equal([]string{"  DOE, JOHN ", "ÑÚÑEZ, ANA", "", "SMITH"}, columnStrings(upper, "upper"),
+		"the values are wrong")
+
+	values, _ := upper.Column("upper")
+	as.True(values[2].IsNull(), "the null values are kept")
+
+	lower, _ := df.StrLower("email", "lower")
+	as.Equal([]string{"john@doe.com", "ana@mail.es", "bob@mail.es", ""},
+		columnStrings(lower, "lower"), "the values are wrong")
+
+	trim, err := df.StrTrim("name", "trim", "")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"Doe, John", "Ñúñez, Ana", "", "smith"}, columnStrings(trim, "trim"),
+		"the values are wrong")
+
+	trim, _ = df.StrTrim("email", "trim", "jes")
+	as.Equal([]string{"ohn@doe.com", "ana@mail.", "bob@mail.", ""}, columnStrings(trim, "trim"),
+		"the values are wrong")
+
+	length, err := df.StrLen("name", "len")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(INT, length.Schema()[3].Type, "the column type is wrong")
+	as.Equal([]string{"12", "10", "", "5"}, columnStrings(length, "len"),
+		"the length counts the characters")
+
+	sub, err := df.StrSubstring("name", "sub", 2, 3)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"Doe", "ñez", "", "ith"}, columnStrings(sub, "sub"), "the values are wrong")
+
+	sub, _ = df.StrSubstring("email", "sub", 4, -1)
+	as.Equal([]string{"@doe.com", "mail.es", "mail.es", ""}, columnStrings(sub, "sub"),
+		"the values are wrong")
+
+	sub, err = df.StrSubstring("email", "sub", 4, math.MaxInt64)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"@doe.com", "mail.es", "mail.es", ""}, columnStrings(sub, "sub"),
+		"the big lengths don't overflow")
+
+	sub, _ = df.StrSubstring("email", "sub", 20, 2)
+	as.Equal([]string{"", "", "", ""}, columnStrings(sub, "sub"), "the values are wrong")
+
+	// padding.
+	ages, _ := df.Cast("age", STRING, CastOptions{})
+	tests := []struct {
+		side     padSide
+		expected []string
+	}{
+		{PAD_LEFT, []string{"0030", "0025", "0041", "0019"}},
+		{PAD_RIGHT, []string{"3000", "2500", "4100", "1900"}},
+		{PAD_BOTH, []string{"0300", "0250", "0410", "0190"}},
+	}
+
+	for _, test := range tests {
+		pad, err := ages.StrPad("age", "pad", 4, "0", test.side)
+		as.Nil(err, "there is an error in the function")
+		as.Equal(test.expected, columnStrings(pad, "pad"), "the pad %d is wrong", test.side)
+	}
+
+	pad, _ := df.StrPad("email", "pad", 5, "·", PAD_LEFT)
+	as.Equal("john@doe.com", columnStrings(pad, "pad")[0], "the long values aren't modified")
+
+	// errors.
+	_, err = df.StrUpper("age", "upper")
+	as.EqualError(err, "StrUpper function is invalid in column type int", "the error is wrong")
+
+	_, err = df.StrLower("none", "lower")
+	as.EqualError(err, "column none not found", "the error is wrong")
+
+	_, err = df.StrUpper("name", "email")
+	as.EqualError(err, "the column email is duplicated", "the error is wrong")
+
+	_, err = df.StrSubstring("name", "sub", -1, 2)
+	as.EqualError(err, "the start index must be non-negative", "the error is wrong")
+
+	_, err = df.StrPad("name", "pad", 10, "ab", PAD_LEFT)
+	as.EqualError(err, "the pad must be a single character", "the error is wrong")
+
+	_, err = df.StrPad("name", "pad", 10, "a", padSide(7))
+	as.EqualError(err, "invalid pad side", "the error is wrong")
+}
+
+func Test_DataFrame_Str_Masks(t *testing.T) {
+	as := assert.New(t)
+	df := makeStringsDataFrame(t)
+
+	mask, err := df.StrContains("email", "mail")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, true, true, false}, mask, "the mask is wrong")
+
+	mask, err = df.StrHasPrefix("email", "ana")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, true, false, false}, mask, "the mask is wrong")
+
+	mask, err = df.StrHasSuffix("email", ".es")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, true, true, false}, mask, "the mask is wrong")
+
+	mask, err = df.StrMatch("name", `^\s*[A-ZÑ]\p{L}+,`)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{true, true, false, false}, mask, "the mask is wrong")
+
+	// category columns.
+	cat, _ := df.AsCategory("email", CategoryOptions{})
+	mask, err = cat.StrContains("email", "mail")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]bool{false, true, true, false}, mask, "the mask is wrong")
+
+	_, err = df.StrMatch("name", "(")
+	as.NotNil(err, "the pattern is invalid")
+
+	_, err = df.StrContains("age", "1")
+	as.EqualError(err, "StrContains function is invalid in column type int", "the error is wrong")
+}
+
+func Test_DataFrame_Str_Regexp(t *testing.T) {
+	as := assert.New(t)
+	df := makeStringsDataFrame(t)
+
+	domain, err := df.StrExtract("email", "domain", `@([\w-]+)\.(\w+)$`, 1)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"doe", "mail", "mail", ""}, columnStrings(domain, "domain"),
+		"the values are wrong")
+
+	domain, _ = df.StrExtract("email", "domain", `@(\w+)\.com$`, 0)
+	values, _ := domain.Column("domain")
+	as.Equal("@doe.com", values[0].String(), "the group 0 is the whole match")
+	as.True(values[1].IsNull(), "the values without match are null")
+
+	optional, _ := df.StrExtract("email", "user", `^(bob)?\w*@`, 1)
+	values, _ = optional.Column("user")
+	as.True(values[0].IsNull(), "the groups out of the match are null")
+	as.Equal("bob", values[2].String(), "the value is wrong")
+
+	replaced, err := df.StrReplace("email", "user", `^(\w+)@.*$`, "user: $1")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"user: john", "user: ana", "user: bob", ""},
+		columnStrings(replaced, "user"), "the values are wrong")
+
+	_, err = df.StrExtract("email", "domain", `@(\w+)`, 2)
+	as.EqualError(err, `the regular expression @(\w+) hasn't the group 2`, "the error is wrong")
+
+	_, err = df.StrReplace("email", "user", "[", "")
+	as.NotNil(err, "the pattern is invalid")
+}
+
+func Test_DataFrame_StrSplit_StrConcat(t *testing.T) {
+	as := assert.New(t)
+	df := makeStringsDataFrame(t)
+
+	split, err := df.StrSplit("email", "@", "user", "domain")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"name", "email", "age", "user", "domain"}, split.Headers(),
+		"the headers are wrong")
+	as.Equal([]string{"john", "ana", "bob", ""}, columnStrings(split, "user"),
+		"the values are wrong")
+	as.Equal([]string{"doe.com", "mail.es", "mail.es", ""}, columnStrings(split, "domain"),
+		"the values are wrong")
+
+	// the last column contains the rest of the value.
+	split, _ = df.StrSplit("email", ".", "a", "b")
+	as.Equal([]string{"com", "es", "es", ""}, columnStrings(split, "b"), "the values are wrong")
+
+	split, _ = df.StrSplit("name", ", ", "last", "first", "other")
+	values, _ := split.Column("first")
+	as.True(values[3].IsNull(), "the columns without part are null")
+	as.Equal([]string{"  Doe", "Ñúñez", "", "smith"}, columnStrings(split, "last"),
+		"the values are wrong")
+
+	_, err = df.StrSplit("email", "@")
+	as.EqualError(err, "there aren't columns to store the parts", "the error is wrong")
+
+	_, err = df.StrSplit("email", "", "a")
+	as.EqualError(err, "the separator can't be empty", "the error is wrong")
+
+	_, err = df.StrSplit("email", "@", "user", "age")
+	as.EqualError(err, "the column age is duplicated", "the error is wrong")
+
+	concat, err := df.StrConcat("label", " - ", "email", "age")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"john@doe.com - 30", "ana@mail.es - 25", "bob@mail.es - 41", ""},
+		columnStrings(concat, "label"), "the values are wrong")
+
+	values, _ = concat.Column("label")
+	as.True(values[3].IsNull(), "the value is null whether some value is null")
+
+	_, err = df.StrConcat("label", "-")
+	as.EqualError(err, "there aren't columns to concatenate", "the error is wrong")
+
+	// the same column can be joined several times.
+	concat, err = df.StrConcat("label", "/", "age", "age")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"30/30", "25/25", "41/41", "19/19"}, columnStrings(concat, "label"),
+		"the values are wrong")
+
+	_, err = df.StrConcat("label", "-", "email", "none")
+	as.EqualError(err, "column none not found", "the error is wrong")
+}
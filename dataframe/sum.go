@@ -0,0 +1,180 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// sumOverflow indicates what the sum does when the sum of an integer column overflows.
+type sumOverflow int8
+
+// The valid overflow policies.
+const (
+	SUM_OVERFLOW_ERROR sumOverflow = 0 // returns an error.
+	SUM_OVERFLOW_FLOAT sumOverflow = 1 // the sum continues exactly and the result is a float.
+	SUM_OVERFLOW_WRAP  sumOverflow = 2 // the sum wraps around, like the go integers.
+)
+
+// bigSumPrecision is the precision of the big floats used by SumBig. It is enough to sum
+// exactly any set of float64 numbers.
+const bigSumPrecision = 2200
+
+// errIntOverflow is the error returned when the sum of the deprecated operations overflows.
+var errIntOverflow = errors.New("the sum overflows")
+
+// SumOptions struct defines how the values of a column are summed. The zero value returns an
+// error when the integer sum overflows and sums the floats without compensation.
+type SumOptions struct {
+	// Overflow is the policy applied when the sum of an int or uint column overflows:
+	// SUM_OVERFLOW_ERROR, SUM_OVERFLOW_FLOAT or SUM_OVERFLOW_WRAP. The decimal sums don't
+	// wrap, so SUM_OVERFLOW_WRAP returns an error with them.
+	Overflow sumOverflow
+	// Compensated flag sums the float and complex columns with the Neumaier algorithm, that
+	// keeps the rounding error of each addition, so the result is accurate even with millions
+	// of values of different magnitudes.
+	Compensated bool
+}
+
+// check checks if the sum options are valid.
+func (o *SumOptions) check() error {
+	switch o.Overflow {
+	case SUM_OVERFLOW_ERROR, SUM_OVERFLOW_FLOAT, SUM_OVERFLOW_WRAP:
+		return nil
+	default:
+		return fmt.Errorf("invalid overflow policy")
+	}
+}
+
+// addInt64 sums a and b. The second value returned is true whether the sum overflows.
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, (b > 0 && sum < a) || (b < 0 && sum > a)
+}
+
+// subInt64 subtracts b from a. The second value returned is true whether the difference
+// overflows.
+func subInt64(a, b int64) (int64, bool) {
+	diff := a - b
+	return diff, (b > 0 && diff > a) || (b < 0 && diff < a)
+}
+
+// mulInt64 multiplies a and b. The second value returned is true whether the product
+// overflows.
+func mulInt64(a, b int64) (int64, bool) {
+	product := a * b
+	return product, a != 0 && (product/a != b || (a == -1 && b == math.MinInt64))
+}
+
+// neumaierAdd adds x to the sum, whose accumulated rounding error is c, using the Neumaier
+// compensated summation. Returns the new sum and the new rounding error.
+func neumaierAdd(sum, c, x float64) (float64, float64) {
+	t := sum + x
+	if math.IsInf(t, 0) || math.IsNaN(t) {
+		return t, c
+	}
+
+	if math.Abs(sum) >= math.Abs(x) {
+		c += (sum - t) + x
+	} else {
+		c += (x - t) + sum
+	}
+
+	return t, c
+}
+
+// SumRangeWithOptions sums the values of the column colName, between rows min and max,
+// using the opts options. The value returned depends of the column type: int64, uint64,
+// float64 or complex128. With the SUM_OVERFLOW_FLOAT policy, the sum of an integer column that
+// overflows is returned as float64.
+//
+// Example:
+//	total, err := df.SumRangeWithOptions("amount", SumOptions{Compensated: true}, 0, 1000)
+func (df *DataFrame) SumRangeWithOptions(
+	colName string, opts SumOptions, min, max int,
+) (interface{}, error) {
+	value, err := df.AggregateRange(NewSumAggregatorWithOptions(colName, opts), min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.rawValue(), nil
+}
+
+// SumWithOptions sums all values of the column colName using the opts options.
+// See SumRangeWithOptions.
+func (df *DataFrame) SumWithOptions(colName string, opts SumOptions) (interface{}, error) {
+	return df.SumRangeWithOptions(colName, opts, 0, df.NumberRows())
+}
+
+// bigSumOperation operation sums exactly the values of a column.
+type bigSumOperation struct {
+	OperationBase
+	ctype columnType
+	i     *big.Int
+	f     *big.Float
+}
+
+// F sums the value of the row.
+func (o *bigSumOperation) F(r *Row) error {
+	v, err := r.Cell(o.colName)
+	if err != nil || v.IsNull() {
+		return err
+	}
+
+	switch o.ctype {
+	case INT:
+		i, _ := v.Int64()
+		o.i.Add(o.i, big.NewInt(i))
+	case UINT:
+		u, _ := v.Uint64()
+		o.i.Add(o.i, new(big.Int).SetUint64(u))
+	case FLOAT:
+		f, _ := v.Float64()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("the value %v of the column %s can't be summed exactly",
+				f, o.colName)
+		}
+
+		o.f.Add(o.f, new(big.Float).SetPrec(bigSumPrecision).SetFloat64(f))
+	}
+
+	return nil
+}
+
+// SumBigRange sums exactly the values of the column colName, between rows min and max,
+// so the result never overflows or loses precision. The value returned depends of the column
+// type: *big.Int for the int and uint columns and *big.Float for the float columns. The null
+// values are ignored. Returns an error if the column type is invalid or the column has
+// infinite or NaN floats.
+func (df *DataFrame) SumBigRange(colName string, min, max int) (interface{}, error) {
+	col, exists := df.getColumnByName(colName)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	op := bigSumOperation{OperationBase{colName}, col.ctype, new(big.Int), nil}
+	switch col.ctype {
+	case INT, UINT:
+	case FLOAT:
+		op.f = new(big.Float).SetPrec(bigSumPrecision)
+	default:
+		return nil, fmt.Errorf("SumBig operation is invalid in column type %s", col.ctype)
+	}
+
+	if err := df.OperationRange(&op, min, max); err != nil {
+		return nil, err
+	}
+
+	if op.f != nil {
+		return op.f, nil
+	}
+
+	return op.i, nil
+}
+
+// SumBig sums exactly all values of the column colName. See SumBigRange.
+func (df *DataFrame) SumBig(colName string) (interface{}, error) {
+	return df.SumBigRange(colName, 0, df.NumberRows())
+}
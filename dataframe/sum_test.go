@@ -0,0 +1,182 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func Test_neumaierAdd_func(t *testing.T) {
+	as := assert.New(t)
+
+	sum, c := 0.0, 0.0
+	for _, f := range []float64{1, 1e100, 1, -1e100} {
+		sum, c = neumaierAdd(sum, c, f)
+	}
+
+	as.Equal(2.0, sum+c, "the compensated sum is wrong")
+
+	sum, c = neumaierAdd(math.MaxFloat64, 0, math.MaxFloat64)
+	as.True(math.IsInf(sum+c, 1), "the sum must be infinite")
+}
+
+func Test_DataFrame_SumWithOptions_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"i": []int64{math.MaxInt64, 10, -20},
+		"u": []uint64{math.MaxUint64, 1, 0},
+		"f": []float64{0.1, 0.2, 0.3},
+		"c": []complex128{0.1 + 1i, 0.2, 0.3 - 1i},
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	// the overflow returns an error by default.
+	_, err = df.Sum("i")
+	as.Equal("the sum of the column i overflows the type int", err.Error(),
+		"the error message doesn't match")
+	_, err = df.SumWithOptions("u", SumOptions{})
+	as.Equal("the sum of the column u overflows the type uint", err.Error(),
+		"the error message doesn't match")
+
+	value, err := df.SumRangeWithOptions("i", SumOptions{}, 1, 3)
+	as.Nil(err, "there is an error in the function")
+	as.Equal(int64(-10), value, "the sum is wrong")
+
+	// the result is a float after an overflow, and an int if the final sum fits.
+	value, err = df.SumWithOptions("u", SumOptions{Overflow: SUM_OVERFLOW_FLOAT})
+	as.Nil(err, "there is an error in the function")
+	as.Equal(float64(math.MaxUint64), value, "the sum is wrong")
+
+	value, _ = df.SumWithOptions("i", SumOptions{Overflow: SUM_OVERFLOW_FLOAT})
+	as.Equal(int64(math.MaxInt64-10), value, "the sum is wrong")
+
+	value, _ = df.SumWithOptions("u", SumOptions{Overflow: SUM_OVERFLOW_WRAP})
+	as.Equal(uint64(0), value, "the sum must wrap around")
+
+	// compensated sum.
+	naive := 0.1
+	naive += 0.2
+	naive += 0.3
+	value, _ = df.SumWithOptions("f", SumOptions{})
+	as.Equal(naive, value, "the naive sum is wrong")
+	value, _ = df.SumWithOptions("f", SumOptions{Compensated: true})
+	as.Equal(0.6, value, "the compensated sum is wrong")
+	value, _ = df.SumWithOptions("c", SumOptions{Compensated: true})
+	as.Equal(complex(0.6, 0), value, "the compensated sum is wrong")
+
+	_, err = df.SumWithOptions("f", SumOptions{Overflow: 5})
+	as.Equal("invalid overflow policy", err.Error(), "the error message doesn't match")
+}
+
+func Test_SumAggregator_Overflow_Parallel(t *testing.T) {
+	as := assert.New(t)
+
+	values := []int64{}
+	for i := 0; i < 100; i++ {
+		values = append(values, math.MaxInt64/50)
+	}
+
+	df, _ := NewDataFrameFromColumns(map[string]interface{}{"a": values})
+
+	value, err := df.AggregateParallel(
+		NewSumAggregatorWithOptions("a", SumOptions{Overflow: SUM_OVERFLOW_FLOAT}), 4)
+	as.Nil(err, "there is an error in the function")
+	f, _ := value.Float64()
+	as.InDelta(float64(math.MaxInt64/50)*100, f, 1e6, "the sum is wrong")
+
+	_, err = df.AggregateParallel(NewSumAggregator("a"), 4)
+	as.NotNil(err, "the sum overflows")
+}
+
+func Test_DataFrame_SumBig_func(t *testing.T) {
+	as := assert.New(t)
+
+	df, _ := NewDataFrameFromColumns(map[string]interface{}{
+		"i": []int64{math.MaxInt64, math.MaxInt64, 1},
+		"u": []uint64{math.MaxUint64, 1, 0},
+		"f": []float64{1e100, 1, -1e100},
+		"n": []float64{1, math.Inf(1), 0},
+		"s": []string{"a", "b", "c"},
+	})
+
+	value, err := df.SumBig("i")
+	as.Nil(err, "there is an error in the function")
+	expected, _ := new(big.Int).SetString("18446744073709551615", 10)
+	as.Equal(0, expected.Cmp(value.(*big.Int)), "the sum is wrong")
+
+	value, _ = df.SumBig("u")
+	expected, _ = new(big.Int).SetString("18446744073709551616", 10)
+	as.Equal(0, expected.Cmp(value.(*big.Int)), "the sum is wrong")
+
+	value, err = df.SumBig("f")
+	as.Nil(err, "there is an error in the function")
+	f, _ := value.(*big.Float).Float64()
+	as.Equal(1.0, f, "the sum is wrong")
+
+	value, _ = df.SumBigRange("i", 2, 3)
+	as.Equal(int64(1), value.(*big.Int).Int64(), "the sum is wrong")
+
+	_, err = df.SumBig("n")
+	as.Equal("the value +Inf of the column n can't be summed exactly", err.Error(),
+		"the error message doesn't match")
+	_, err = df.SumBig("s")
+	as.Equal("SumBig operation is invalid in column type string", err.Error(),
+		"the error message doesn't match")
+	_, err = df.SumBig("none")
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_mulInt64_func(t *testing.T) {
+	as := assert.New(t)
+
+	tests := []struct {
+		a, b     int64
+		expected int64
+		overflow bool
+	}{
+		{-3, 4, -12, false},
+		{math.MinInt64, 1, math.MinInt64, false},
+		{math.MinInt64, -1, 0, true},
+		{-1, math.MinInt64, 0, true},
+		{1 << 32, 1 << 31, 0, true},
+		{0, math.MaxInt64, 0, false},
+	}
+
+	for _, test := range tests {
+		product, overflow := mulInt64(test.a, test.b)
+		as.Equal(test.overflow, overflow, "the overflow of %d * %d is wrong", test.a, test.b)
+		if !test.overflow {
+			as.Equal(test.expected, product, "the product of %d * %d is wrong", test.a, test.b)
+		}
+	}
+}
+
+func Test_subInt64_func(t *testing.T) {
+	as := assert.New(t)
+
+	tests := []struct {
+		a, b     int64
+		expected int64
+		overflow bool
+	}{
+		{-3, 4, -7, false},
+		{math.MinInt64, 1, 0, true},
+		{math.MaxInt64, -1, 0, true},
+		{0, math.MinInt64, 0, true},
+		{-1, math.MinInt64, math.MaxInt64, false},
+		{math.MinInt64, math.MinInt64, 0, false},
+	}
+
+	for _, test := range tests {
+		diff, overflow := subInt64(test.a, test.b)
+		as.Equal(test.overflow, overflow, "the overflow of %d - %d is wrong", test.a, test.b)
+		if !test.overflow {
+			as.Equal(test.expected, diff, "the difference of %d - %d is wrong", test.a, test.b)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package dataframe
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// rankedRow is a row candidate to the first rows of an order, with the values of the order
+// columns.
+type rankedRow struct {
+	index  int
+	values []Value
+}
+
+// rowRanker compares the ranked rows using an order.
+type rowRanker struct {
+	order []internalOrderColumn
+	// Comparer functions of the order columns.
+	comparers []func(a, b Value) (Comparers, error)
+	// reverse flag ranks the rows in the inverse order.
+	reverse bool
+}
+
+// newRowRanker creates a new rowRanker with the order.
+func newRowRanker(order []internalOrderColumn, reverse bool) *rowRanker {
+	r := rowRanker{order: order, reverse: reverse}
+	for i := range order {
+		r.comparers = append(r.comparers, order[i].valueComparer())
+	}
+
+	return &r
+}
+
+// before returns true if the a row is placed before the b row. The rows with equal values
+// keep the order of their indexes, so the ranking is stable.
+func (r *rowRanker) before(a, b *rankedRow) bool {
+	for i, f := range r.comparers {
+		comp, _ := r.order[i].compare(f, a.values[i], b.values[i])
+		if comp != EQUAL {
+			return (comp == LESS) != r.reverse
+		}
+	}
+
+	return (a.index < b.index) != r.reverse
+}
+
+// rankHeap is a bounded heap with the first n rows of the ranker order. The root of the heap
+// is the last of these rows, so it is replaced when a row placed before it is added.
+type rankHeap struct {
+	ranker *rowRanker
+	rows   []*rankedRow
+}
+
+func (h *rankHeap) Len() int { return len(h.rows) }
+
+func (h *rankHeap) Less(i, j int) bool { return h.ranker.before(h.rows[j], h.rows[i]) }
+
+func (h *rankHeap) Swap(i, j int) { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+
+func (h *rankHeap) Push(x interface{}) { h.rows = append(h.rows, x.(*rankedRow)) }
+
+func (h *rankHeap) Pop() interface{} {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
+
+// add adds the row to the heap, whether it is one of the first n rows.
+func (h *rankHeap) add(row *rankedRow, n int) {
+	if len(h.rows) < n {
+		heap.Push(h, row)
+	} else if n > 0 && h.ranker.before(row, h.rows[0]) {
+		h.rows[0] = row
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the row indexes of the heap in the ranker order.
+func (h *rankHeap) sorted() []int {
+	rows := append([]*rankedRow{}, h.rows...)
+	sort.Slice(rows, func(i, j int) bool { return h.ranker.before(rows[i], rows[j]) })
+
+	indexes := make([]int, len(rows))
+	for i, row := range rows {
+		indexes[i] = row.index
+	}
+
+	return indexes
+}
+
+// internalOrder transforms the order columns in the internal order, with the default order
+// options. Returns an error if a column doesn't exist.
+func (df *DataFrame) internalOrder(orderColumns []OrderColumn) ([]internalOrderColumn, error) {
+	order := []internalOrderColumn{}
+	for _, oc := range orderColumns {
+		col, exists := df.getColumnByName(oc.Name)
+		if !exists {
+			return nil, fmt.Errorf("column %s not found", oc.Name)
+		}
+
+		order = append(order, internalOrderColumn{col, oc.Order, OrderOptions{}})
+	}
+
+	return order, nil
+}
+
+// lockedRankedRow returns the ranked row of the index row, with the values of the order
+// columns. The caller must hold the read lock of the DataFrame.
+func (df *DataFrame) lockedRankedRow(index int, order []internalOrderColumn) *rankedRow {
+	row := rankedRow{index: index, values: make([]Value, len(order))}
+	for i, ocol := range order {
+		row.values[i], _ = df.handler.Get(index, ocol.column.name)
+	}
+
+	return &row
+}
+
+// lockedRows returns a copy of the rows of the indexes. The caller must hold the read lock
+// of the DataFrame.
+func (df *DataFrame) lockedRows(indexes []int) []map[string]Value {
+	data := make([]map[string]Value, len(indexes))
+	for i, index := range indexes {
+		data[i] = make(map[string]Value, len(df.columns))
+		for _, col := range df.columns {
+			data[i][col.name], _ = df.handler.Get(index, col.name)
+		}
+	}
+
+	return data
+}
+
+// topN returns a new DataFrame with the first n rows of the order. If reverse is true, it
+// returns the last n rows of the order.
+func (df *DataFrame) topN(n int, orderColumns []OrderColumn, reverse bool) (*DataFrame, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("the number of rows must be non-negative")
+	}
+
+	order, err := df.internalOrder(orderColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	h := rankHeap{ranker: newRowRanker(order, reverse)}
+	for i := 0; i < df.handler.Len(); i++ {
+		h.add(df.lockedRankedRow(i, order), n)
+	}
+
+	indexes := h.sorted()
+	if reverse {
+		for i, j := 0, len(indexes)-1; i < j; i, j = i+1, j-1 {
+			indexes[i], indexes[j] = indexes[j], indexes[i]
+		}
+	}
+
+	return newDataFrameFromData(df.columns, df.lockedRows(indexes)), nil
+}
+
+// TopN returns a new DataFrame with the first n rows of the DataFrame ordered by the
+// orderColumns, like the Order function, without ordering all rows. It uses a heap of n
+// rows, so it is faster than Order when n is small. The rows with equal values keep the
+// current order, and the NaN floats are after the numbers, like in Order. The DataFrame
+// isn't modified.
+//
+// Example:
+//	// the 100 rows with the highest score.
+//	top, err := df.TopN(100, OrderColumn{"score", DESC})
+func (df *DataFrame) TopN(n int, orderColumns ...OrderColumn) (*DataFrame, error) {
+	return df.topN(n, orderColumns, false)
+}
+
+// BottomN returns a new DataFrame with the last n rows of the DataFrame ordered by the
+// orderColumns, like the Order function, without ordering all rows. The rows are in the
+// same order as in the ordered DataFrame. The DataFrame isn't modified.
+func (df *DataFrame) BottomN(n int, orderColumns ...OrderColumn) (*DataFrame, error) {
+	return df.topN(n, orderColumns, true)
+}
+
+// isNaNValue checks if the v value is a NaN float or a complex with a NaN part.
+func isNaNValue(v Value) bool {
+	switch raw := v.rawValue().(type) {
+	case float64:
+		return math.IsNaN(raw)
+	case complex128:
+		return cmplx.IsNaN(raw)
+	default:
+		return false
+	}
+}
+
+// argFirst returns the index of the first row of the colName column in the ordType order.
+// The null values and the NaN floats are ignored.
+func (df *DataFrame) argFirst(colName string, ordType orderType) (int, error) {
+	order, err := df.internalOrder([]OrderColumn{{colName, ordType}})
+	if err != nil {
+		return -1, err
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	h := rankHeap{ranker: newRowRanker(order, false)}
+	for i := 0; i < df.handler.Len(); i++ {
+		row := df.lockedRankedRow(i, order)
+		if !row.values[0].IsNull() && !isNaNValue(row.values[0]) {
+			h.add(row, 1)
+		}
+	}
+
+	if len(h.rows) == 0 {
+		return -1, fmt.Errorf("the column %s hasn't values", colName)
+	}
+
+	return h.rows[0].index, nil
+}
+
+// ArgMax returns the index of the row with the max value of the colName column. If several
+// rows have the max value, it returns the first of them. The null values and the NaN floats
+// are ignored. Returns an error if the column doesn't exist or it hasn't values.
+func (df *DataFrame) ArgMax(colName string) (int, error) {
+	return df.argFirst(colName, DESC)
+}
+
+// ArgMin returns the index of the row with the min value of the colName column. If several
+// rows have the min value, it returns the first of them. The null values and the NaN floats
+// are ignored. Returns an error if the column doesn't exist or it hasn't values.
+func (df *DataFrame) ArgMin(colName string) (int, error) {
+	return df.argFirst(colName, ASC)
+}
+
+// nFirstByGroup returns a new DataFrame with the first n rows of each group in the ordType
+// order of the colName column. The groups are made with the values of the groupCols columns.
+func (df *DataFrame) nFirstByGroup(
+	n int, colName string, ordType orderType, groupCols []string,
+) (*DataFrame, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("the number of rows must be non-negative")
+	}
+
+	order, err := df.internalOrder([]OrderColumn{{colName, ordType}})
+	if err != nil {
+		return nil, err
+	}
+
+	groupColumns := []column{}
+	if len(groupCols) > 0 {
+		if groupColumns, err = df.selectColumns(groupCols); err != nil {
+			return nil, err
+		}
+	}
+
+	df.lock.RLock()
+	defer df.lock.RUnlock()
+
+	ranker := newRowRanker(order, false)
+	groups := map[string]*rankHeap{}
+	keys := []string{}
+	values := make([]Value, len(groupColumns))
+
+	for i := 0; i < df.handler.Len(); i++ {
+		row := df.lockedRankedRow(i, order)
+		if row.values[0].IsNull() || isNaNValue(row.values[0]) {
+			continue
+		}
+
+		for j, col := range groupColumns {
+			values[j], _ = df.handler.Get(i, col.name)
+		}
+
+		key := valuesKey(values)
+		h, exists := groups[key]
+		if !exists {
+			h = &rankHeap{ranker: ranker}
+			groups[key] = h
+			keys = append(keys, key)
+		}
+
+		h.add(row, n)
+	}
+
+	indexes := []int{}
+	for _, key := range keys {
+		indexes = append(indexes, groups[key].sorted()...)
+	}
+
+	return newDataFrameFromData(df.columns, df.lockedRows(indexes)), nil
+}
+
+// NLargest returns a new DataFrame with the n rows with the largest values of the colName
+// column in each group, made with the values of the groupCols columns. If groupCols is
+// empty, all rows are a single group. The groups are in order of appearance and the rows of
+// each group are ordered from the largest value. The null values and the NaN floats are
+// ignored.
+//
+// Example:
+//	// the 3 best sellers of each country.
+//	best, err := df.NLargest(3, "sales", "country")
+func (df *DataFrame) NLargest(n int, colName string, groupCols ...string) (*DataFrame, error) {
+	return df.nFirstByGroup(n, colName, DESC, groupCols)
+}
+
+// NSmallest returns a new DataFrame with the n rows with the smallest values of the colName
+// column in each group, made with the values of the groupCols columns. If groupCols is
+// empty, all rows are a single group. The groups are in order of appearance and the rows of
+// each group are ordered from the smallest value. The null values and the NaN floats are
+// ignored.
+func (df *DataFrame) NSmallest(n int, colName string, groupCols ...string) (*DataFrame, error) {
+	return df.nFirstByGroup(n, colName, ASC, groupCols)
+}
@@ -0,0 +1,170 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// makeTopNDataFrame returns a DataFrame with the columns team, player and score.
+func makeTopNDataFrame(t *testing.T) *DataFrame {
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"team":   []string{"a", "b", "a", "b", "a", "c", "a"},
+		"player": []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7"},
+		"score": []*float64{
+			floatPtr(3), floatPtr(7), floatPtr(7), nil, floatPtr(1), floatPtr(0), floatPtr(5),
+		},
+	}, "team", "player", "score")
+
+	if err != nil {
+		assert.FailNow(t, "error creating DataFrame", "error: %s", err.Error())
+	}
+
+	return df
+}
+
+// columnStrings returns the values of the colName column as strings.
+func columnStrings(df *DataFrame, colName string) []string {
+	values := []string{}
+	iterator := df.Iterator()
+	for row, cont := iterator.Next(); cont; row, cont = iterator.Next() {
+		v, _ := row.Cell(colName)
+		values = append(values, v.String())
+	}
+
+	return values
+}
+
+func Test_DataFrame_TopN_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeTopNDataFrame(t)
+
+	top, err := df.TopN(3, OrderColumn{"score", DESC})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"p2", "p3", "p7"}, columnStrings(top, "player"), "the rows are wrong")
+
+	// the null values are the last.
+	top, _ = df.TopN(10, OrderColumn{"score", ASC})
+	as.Equal([]string{"p6", "p5", "p1", "p7", "p2", "p3", "p4"}, columnStrings(top, "player"),
+		"the rows are wrong")
+
+	top, _ = df.TopN(2, OrderColumn{"team", DESC}, OrderColumn{"score", ASC})
+	as.Equal([]string{"p6", "p2"}, columnStrings(top, "player"), "the rows are wrong")
+
+	top, _ = df.TopN(0, OrderColumn{"score", DESC})
+	as.Equal(0, top.NumberRows(), "the DataFrame must be empty")
+
+	// the DataFrame isn't modified.
+	as.Equal("p1", columnStrings(df, "player")[0], "the DataFrame has been modified")
+
+	_, err = df.TopN(-1, OrderColumn{"score", DESC})
+	as.Equal("the number of rows must be non-negative", err.Error(),
+		"the error message doesn't match")
+	_, err = df.TopN(1, OrderColumn{"none", DESC})
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_BottomN_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeTopNDataFrame(t)
+
+	bottom, err := df.BottomN(3, OrderColumn{"score", DESC})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"p5", "p6", "p4"}, columnStrings(bottom, "player"), "the rows are wrong")
+
+	// the equal rows keep the order of the ordered DataFrame.
+	bottom, _ = df.BottomN(3, OrderColumn{"team", ASC})
+	as.Equal([]string{"p2", "p4", "p6"}, columnStrings(bottom, "player"), "the rows are wrong")
+
+	bottom, _ = df.BottomN(5, OrderColumn{"team", ASC})
+	ordered := makeTopNDataFrame(t)
+	ordered.Order(OrderColumn{"team", ASC})
+	as.Equal(columnStrings(ordered, "player")[2:], columnStrings(bottom, "player"),
+		"the rows must be the last rows of the ordered DataFrame")
+}
+
+func Test_DataFrame_TopN_BottomN_NaN(t *testing.T) {
+	as := assert.New(t)
+	nan := math.NaN()
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"f": []float64{1, nan, 3, 2, nan, 5},
+	})
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	// the NaN values are after the numbers in both order types, like in Order.
+	top, err := df.TopN(3, OrderColumn{"f", DESC})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"5", "3", "2"}, columnStrings(top, "f"), "the rows are wrong")
+
+	top, _ = df.TopN(3, OrderColumn{"f", ASC})
+	as.Equal([]string{"1", "2", "3"}, columnStrings(top, "f"), "the rows are wrong")
+
+	bottom, err := df.BottomN(3, OrderColumn{"f", DESC})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"1", "NaN", "NaN"}, columnStrings(bottom, "f"), "the rows are wrong")
+
+	bottom, _ = df.BottomN(4, OrderColumn{"f", ASC})
+	as.Equal([]string{"3", "5", "NaN", "NaN"}, columnStrings(bottom, "f"),
+		"the rows are wrong")
+}
+
+func Test_DataFrame_ArgMax_ArgMin_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeTopNDataFrame(t)
+
+	index, err := df.ArgMax("score")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(1, index, "the index is wrong")
+
+	index, err = df.ArgMin("score")
+	as.Nil(err, "there is an error in the function")
+	as.Equal(5, index, "the index is wrong")
+
+	index, _ = df.ArgMax("player")
+	as.Equal(6, index, "the index is wrong")
+
+	// the NaN and null values are ignored.
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"a": []*float64{nil, floatPtr(math.NaN()), floatPtr(-2), floatPtr(4)},
+		"b": []*int{nil, nil, nil, nil},
+	})
+
+	index, _ = df.ArgMin("a")
+	as.Equal(2, index, "the index is wrong")
+	index, _ = df.ArgMax("a")
+	as.Equal(3, index, "the index is wrong")
+
+	_, err = df.ArgMax("b")
+	as.Equal("the column b hasn't values", err.Error(), "the error message doesn't match")
+	_, err = df.ArgMin("c")
+	as.Equal("column c not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_NLargest_NSmallest_func(t *testing.T) {
+	as := assert.New(t)
+	df := makeTopNDataFrame(t)
+
+	largest, err := df.NLargest(2, "score", "team")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"p3", "p7", "p2", "p6"}, columnStrings(largest, "player"),
+		"the rows are wrong")
+
+	smallest, err := df.NSmallest(1, "score", "team")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"p5", "p2", "p6"}, columnStrings(smallest, "player"), "the rows are wrong")
+
+	// without groups.
+	largest, _ = df.NLargest(2, "score")
+	as.Equal([]string{"p2", "p3"}, columnStrings(largest, "player"), "the rows are wrong")
+	as.Equal(df.Headers(), largest.Headers(), "the headers are wrong")
+
+	_, err = df.NLargest(2, "score", "none")
+	as.Equal("column none not found", err.Error(), "the error message doesn't match")
+	_, err = df.NSmallest(-2, "score")
+	as.Equal("the number of rows must be non-negative", err.Error(),
+		"the error message doesn't match")
+}
@@ -3,7 +3,10 @@ package dataframe
 import (
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +18,7 @@ import (
 //	- FloatType
 //	- ComplexType
 //	- StringType
+//	- DecimalType
 //
 // Example:
 //	// Float custom type.
@@ -93,6 +97,15 @@ type StringType interface {
 	Compare(v string) Comparers
 }
 
+// DecimalType interface is used to create custom decimal types for the DataFrame columns.
+type DecimalType interface {
+	BaseType
+	// Value returns the DataFrame value stored in the struct as decimal.
+	Value() Decimal
+	// Compare compare the DataFrame value stored in the struct with the param.
+	Compare(v Decimal) Comparers
+}
+
 // Comparers is the variable type that returns the Compare functions in The *ValueTypes*
 type Comparers int8
 
@@ -270,10 +283,14 @@ func (s simpleStringType) String() string {
 
 // Value is the struct where save a DataFrame cell value.
 type Value struct {
-	// DataFrame value. The type only must be one of the "ValueTypes"
+	// DataFrame value. The type only must be one of the "ValueTypes", or nil when the
+	// value is null.
 	value interface{}
 }
 
+// errNullValue is the error returned when it tries to cast a null value.
+var errNullValue = errors.New("value is null")
+
 // newValue creates a new Value using as value the v param.
 // Whether v is not a *ValueTypes* then returns an errors.
 func newValue(v interface{}) (*Value, error) {
@@ -282,7 +299,8 @@ func newValue(v interface{}) (*Value, error) {
 		UintType,
 		FloatType,
 		ComplexType,
-		StringType:
+		StringType,
+		DecimalType:
 
 		return &Value{v}, nil
 	default:
@@ -291,6 +309,42 @@ func newValue(v interface{}) (*Value, error) {
 	}
 }
 
+// newIntValue creates a new Value, type int, with the i number.
+func newIntValue(i int64) Value {
+	return Value{simpleIntType{i}}
+}
+
+// newUintValue creates a new Value, type uint, with the u number.
+func newUintValue(u uint64) Value {
+	return Value{simpleUintType{u}}
+}
+
+// newFloatValue creates a new Value, type float, with the f number.
+func newFloatValue(f float64) Value {
+	return Value{simpleFloatType{f}}
+}
+
+// newComplexValue creates a new Value, type complex, with the c number.
+func newComplexValue(c complex128) Value {
+	return Value{simpleComplexType{c}}
+}
+
+// newDecimalValue creates a new Value, type decimal, with the d decimal.
+func newDecimalValue(d Decimal) Value {
+	return Value{d}
+}
+
+// newStringValue creates a new Value, type string, with the str string.
+func newStringValue(str string) Value {
+	return Value{simpleStringType{str}}
+}
+
+// IsNull returns true whether the value is null.
+// The null values are made from the nil pointers of the DataFrame struct fields.
+func (v *Value) IsNull() bool {
+	return v.value == nil
+}
+
 // checkType checks if the value stored in v object implements a valid interface for handle
 // the type gived by the t param.
 //
@@ -298,6 +352,8 @@ func newValue(v interface{}) (*Value, error) {
 // Any else returns false. If v.value is an invalid type then the function execute a panic func.
 func (v *Value) checkType(t reflect.Kind) bool {
 	switch v.value.(type) {
+	case nil:
+		return false
 	case IntType:
 		return t == reflect.Int
 	case UintType:
@@ -308,14 +364,20 @@ func (v *Value) checkType(t reflect.Kind) bool {
 		return t == reflect.Complex128
 	case StringType:
 		return t == reflect.String
+	case DecimalType:
+		return t == reflect.Struct
 	default:
 		panic("invalid value type")
 	}
 }
 
 // IntType casts the v.value variable in IntType.
-// It generates an error if the casting is impossible.
+// It generates an error if the casting is impossible or the value is null.
 func (v *Value) IntType() (IntType, error) {
+	if v.IsNull() {
+		return simpleIntType{0}, errNullValue
+	}
+
 	ok := v.checkType(reflect.Int)
 
 	if !ok {
@@ -368,8 +430,12 @@ func (v *Value) Int8() (int8, error) {
 }
 
 // UintType casts the v.value variable in UintType.
-// It generates an error if the casting is impossible.
+// It generates an error if the casting is impossible or the value is null.
 func (v *Value) UintType() (UintType, error) {
+	if v.IsNull() {
+		return simpleUintType{0}, errNullValue
+	}
+
 	ok := v.checkType(reflect.Uint)
 
 	if !ok {
@@ -422,8 +488,12 @@ func (v *Value) Uint8() (uint8, error) {
 }
 
 // FloatType casts the v.value variable in FloatType.
-// It generates an error if the casting is impossible.
+// It generates an error if the casting is impossible or the value is null.
 func (v *Value) FloatType() (FloatType, error) {
+	if v.IsNull() {
+		return simpleFloatType{0}, errNullValue
+	}
+
 	ok := v.checkType(reflect.Float64)
 
 	if !ok {
@@ -455,8 +525,12 @@ func (v *Value) Float32() (float32, error) {
 }
 
 // ComplexType casts the v.value variable in ComplexType.
-// It generates an error if the casting is impossible.
+// It generates an error if the casting is impossible or the value is null.
 func (v *Value) ComplexType() (ComplexType, error) {
+	if v.IsNull() {
+		return simpleComplexType{0}, errNullValue
+	}
+
 	ok := v.checkType(reflect.Complex128)
 
 	if !ok {
@@ -488,8 +562,12 @@ func (v *Value) Complex64() (complex64, error) {
 }
 
 // StringType casts the v.value variable in StringType.
-// It generates an error if the casting is impossible.
+// It generates an error if the casting is impossible or the value is null.
 func (v *Value) StringType() (StringType, error) {
+	if v.IsNull() {
+		return simpleStringType{""}, errNullValue
+	}
+
 	ok := v.checkType(reflect.String)
 
 	if !ok {
@@ -507,9 +585,37 @@ func (v *Value) Str() (string, error) {
 	return i.Value(), err
 }
 
-// String casts all valid values to string and return they.
-// If the value is not valid then throw and panic error.
+// DecimalType casts the v.value variable in DecimalType.
+// It generates an error if the casting is impossible or the value is null.
+func (v *Value) DecimalType() (DecimalType, error) {
+	if v.IsNull() {
+		return Decimal{}, errNullValue
+	}
+
+	ok := v.checkType(reflect.Struct)
+
+	if !ok {
+		return Decimal{}, errors.New("value type is not decimal")
+	}
+
+	r, _ := v.value.(DecimalType)
+	return r, nil
+}
+
+// Decimal casts the v.value variable in a decimal. v.value variable only will cast in decimal
+// if is type DecimalType. Any else type returns an error.
+func (v *Value) Decimal() (Decimal, error) {
+	i, err := v.DecimalType()
+	return i.Value(), err
+}
+
+// String casts all valid values to string and return they. The null values are
+// returned as an empty string. If the value is not valid then throw and panic error.
 func (v *Value) String() string {
+	if v.IsNull() {
+		return ""
+	}
+
 	val, ok := v.value.(BaseType)
 
 	if !ok {
@@ -518,3 +624,199 @@ func (v *Value) String() string {
 
 	return val.String()
 }
+
+// toNumber returns the value as float64. `v.value` must has the `IntType`, `UintType`,
+// `FloatType` or `DecimalType` type. If not returns an error.
+func (v *Value) toNumber() (float64, error) {
+	switch t := v.value.(type) {
+	case nil:
+		return 0, errNullValue
+	case IntType:
+		return float64(t.Value()), nil
+	case UintType:
+		return float64(t.Value()), nil
+	case FloatType:
+		return t.Value(), nil
+	case DecimalType:
+		return t.Value().Float64(), nil
+	default:
+		return 0, errors.New("value type is not a number")
+	}
+}
+
+// rawValue returns the value as a go basic type: int64, uint64, float64, complex128 or
+// string. The null values are returned as nil.
+func (v *Value) rawValue() interface{} {
+	switch t := v.value.(type) {
+	case IntType:
+		return t.Value()
+	case UintType:
+		return t.Value()
+	case FloatType:
+		return t.Value()
+	case ComplexType:
+		return t.Value()
+	case StringType:
+		return t.Value()
+	case DecimalType:
+		return t.Value()
+	default:
+		return nil
+	}
+}
+
+// positiveZero returns f, turning -0 into +0, because both zeros are equal.
+func positiveZero(f float64) float64 {
+	if f == 0 {
+		return 0
+	}
+
+	return f
+}
+
+// writeKey writes in the buf builder a representation of the value that identifies it.
+// Two values have the same representation whether they have the same type and the same
+// value, so the representation can be used as map key.
+func (v *Value) writeKey(buf *strings.Builder) {
+	var tag byte
+	var str string
+
+	switch t := v.value.(type) {
+	case nil:
+		tag = 'n'
+	case *categoryType:
+		tag, str = 'k', t.Value()
+	case IntType:
+		tag, str = 'i', strconv.FormatInt(t.Value(), 10)
+	case UintType:
+		tag, str = 'u', strconv.FormatUint(t.Value(), 10)
+	case FloatType:
+		tag, str = 'f', strconv.FormatFloat(positiveZero(t.Value()), 'g', -1, 64)
+	case ComplexType:
+		c := t.Value()
+		c = complex(positiveZero(real(c)), positiveZero(imag(c)))
+		tag, str = 'c', strconv.FormatComplex(c, 'g', -1, 128)
+	case StringType:
+		tag, str = 's', t.Value()
+	case DecimalType:
+		tag, str = 'd', t.Value().normalized()
+	}
+
+	buf.WriteByte(tag)
+	buf.WriteString(strconv.Itoa(len(str)))
+	buf.WriteByte(':')
+	buf.WriteString(str)
+}
+
+// valuesKey returns a string that identifies the values array. It is used to group the
+// DataFrame rows by the values of several columns.
+func valuesKey(values []Value) string {
+	var buf strings.Builder
+	for i := range values {
+		values[i].writeKey(&buf)
+	}
+
+	return buf.String()
+}
+
+// Kind returns the column type of the value: INT, UINT, FLOAT, COMPLEX, STRING, CATEGORY or
+// DECIMAL.
+// The null values haven't type, so it returns an empty column type.
+func (v *Value) Kind() columnType {
+	switch v.value.(type) {
+	case *categoryType:
+		return CATEGORY
+	case IntType:
+		return INT
+	case UintType:
+		return UINT
+	case FloatType:
+		return FLOAT
+	case ComplexType:
+		return COMPLEX
+	case StringType:
+		return STRING
+	case DecimalType:
+		return DECIMAL
+	default:
+		return columnType("")
+	}
+}
+
+// Equal returns true whether v and other have the same type and the same value. The
+// values of the custom types are equal whether the `Value` functions return the same value.
+// Two null values are equal, and the NaN floats are equal between them, so Equal can be used
+// to group and deduplicate values. The zeros, -0 and +0, are equal too.
+func (v *Value) Equal(other Value) bool {
+	return valuesKey([]Value{*v}) == valuesKey([]Value{other})
+}
+
+// Hash returns a hash of the value. Two equal values, according to the Equal function,
+// always have the same hash.
+func (v *Value) Hash() uint64 {
+	var buf strings.Builder
+	v.writeKey(&buf)
+
+	h := fnv.New64a()
+	h.Write([]byte(buf.String()))
+	return h.Sum64()
+}
+
+// Compare compares v with the other value, using the Compare function of the v type.
+// Both values must have the same type. It returns an error if the types are different or
+// some value is null.
+func (v *Value) Compare(other Value) (Comparers, error) {
+	if v.IsNull() || other.IsNull() {
+		return EQUAL, errNullValue
+	}
+
+	kind := v.Kind()
+	if kind != other.Kind() {
+		return EQUAL, fmt.Errorf("the value types are different: %s and %s", kind, other.Kind())
+	}
+
+	return columnComparer(kind)(*v, other)
+}
+
+// CompareNumeric compares v with the other value like Compare, but the int, uint and float
+// values can be compared between them, promoting the values to a common type. The int and
+// uint values are compared without loss of precision.
+func (v *Value) CompareNumeric(other Value) (Comparers, error) {
+	if v.IsNull() || other.IsNull() {
+		return EQUAL, errNullValue
+	}
+
+	a, b := v.Kind(), other.Kind()
+	if a == b || !isNumberType(a) || !isNumberType(b) {
+		return v.Compare(other)
+	}
+
+	switch {
+	case a == FLOAT || b == FLOAT:
+		x, _ := v.toNumber()
+		y, _ := other.toNumber()
+		return compareFloats(x, y), nil
+	case a == INT:
+		x, _ := v.Int64()
+		y, _ := other.Uint64()
+		return compareIntUint(x, y), nil
+	default:
+		x, _ := other.Int64()
+		y, _ := v.Uint64()
+		return -compareIntUint(x, y), nil
+	}
+}
+
+// isNumberType returns true whether ctype is INT, UINT or FLOAT.
+func isNumberType(ctype columnType) bool {
+	return ctype == INT || ctype == UINT || ctype == FLOAT
+}
+
+// compareIntUint compares the i int with the u uint.
+func compareIntUint(i int64, u uint64) Comparers {
+	if i < 0 || u > math.MaxInt64 {
+		return LESS
+	}
+
+	return simpleIntType{i}.Compare(int64(u))
+}
@@ -2,6 +2,7 @@ package dataframe
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -476,3 +477,150 @@ func Test_Value_String_func(t *testing.T) {
 	genTest(simpleComplexType{3.2 - 3i}, "3.2-3i", "complex")
 	genTest(simpleStringType{"test"}, "test", "string")
 }
+
+func Test_Value_IsNull_func(t *testing.T) {
+	as := assert.New(t)
+
+	v, _ := newValue(simpleIntType{0})
+	as.False(v.IsNull(), "the value isn't null")
+
+	null := Value{}
+	as.True(null.IsNull(), "the value is null")
+	as.Equal("", null.String(), "the null values are empty strings")
+
+	// casting a null value.
+	_, err := null.Int64()
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+	_, err = null.Uint64()
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+	_, err = null.Float64()
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+	_, err = null.Complex128()
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+	_, err = null.Str()
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+}
+
+func Test_valuesKey_func(t *testing.T) {
+	as := assert.New(t)
+
+	key := func(values ...Value) string {
+		return valuesKey(values)
+	}
+
+	as.Equal(key(newIntValue(1), newStringValue("a")), key(newIntValue(1), newStringValue("a")),
+		"the keys must be equal")
+	as.Equal(key(Value{simpleIntType{3}}), key(newIntValue(3)), "the keys must be equal")
+
+	as.NotEqual(key(newIntValue(1)), key(newUintValue(1)), "the keys must be different")
+	as.NotEqual(key(newIntValue(1)), key(Value{}), "the keys must be different")
+	as.NotEqual(key(newStringValue("a1"), newStringValue("")),
+		key(newStringValue("a"), newStringValue("1")), "the keys must be different")
+	as.NotEqual(key(newFloatValue(0.1)), key(newFloatValue(0.1000001)),
+		"the keys must be different")
+}
+
+func Test_Value_Kind_func(t *testing.T) {
+	as := assert.New(t)
+	values := map[columnType]Value{
+		INT:     newIntValue(1),
+		UINT:    newUintValue(1),
+		FLOAT:   newFloatValue(1),
+		COMPLEX: newComplexValue(1),
+		STRING:  newStringValue("1"),
+	}
+
+	for ctype, v := range values {
+		as.Equal(ctype, v.Kind(), "the kind of the value is wrong")
+	}
+
+	null := Value{}
+	as.Equal(columnType(""), null.Kind(), "the null values haven't kind")
+}
+
+func Test_Value_Equal_func(t *testing.T) {
+	as := assert.New(t)
+	v := newIntValue(3)
+
+	as.True(v.Equal(newIntValue(3)), "the values are equal")
+	as.True(v.Equal(Value{simpleIntType{3}}), "the values are equal")
+	as.False(v.Equal(newIntValue(4)), "the values are different")
+	as.False(v.Equal(newUintValue(3)), "the values are different")
+	as.False(v.Equal(Value{}), "the values are different")
+
+	null := Value{}
+	as.True(null.Equal(Value{}), "the null values are equal")
+
+	nan := newFloatValue(math.NaN())
+	as.True(nan.Equal(newFloatValue(math.NaN())), "the NaN values are equal")
+
+	negZero := math.Copysign(0, -1)
+	zero, f := newFloatValue(negZero), newFloatValue(0)
+	as.True(zero.Equal(f), "the zeros are equal")
+	as.Equal(zero.Hash(), f.Hash(), "the hashes of the zeros must be equal")
+
+	c, z := newComplexValue(complex(negZero, negZero)), newComplexValue(0)
+	as.True(c.Equal(z), "the complex zeros are equal")
+	as.Equal(c.Hash(), z.Hash(), "the hashes of the zeros must be equal")
+}
+
+func Test_Value_Hash_func(t *testing.T) {
+	as := assert.New(t)
+	v := newStringValue("abc")
+
+	w, x := newStringValue("abc"), newStringValue("abd")
+	as.Equal(v.Hash(), w.Hash(), "the hashes must be equal")
+	as.NotEqual(v.Hash(), x.Hash(), "the hashes must be different")
+
+	i, u := newIntValue(1), newUintValue(1)
+	as.NotEqual(i.Hash(), u.Hash(), "the hashes must be different")
+}
+
+func Test_Value_Compare_func(t *testing.T) {
+	as := assert.New(t)
+	v := newIntValue(3)
+
+	c, err := v.Compare(newIntValue(4))
+	as.Nil(err, "there is an error in the function")
+	as.Equal(LESS, c, "the comparation is wrong")
+	c, _ = v.Compare(newIntValue(3))
+	as.Equal(EQUAL, c, "the comparation is wrong")
+	c, _ = v.Compare(newIntValue(2))
+	as.Equal(GREAT, c, "the comparation is wrong")
+
+	s := newStringValue("b")
+	c, _ = s.Compare(newStringValue("a"))
+	as.Equal(GREAT, c, "the comparation is wrong")
+
+	_, err = v.Compare(newFloatValue(3))
+	as.Equal("the value types are different: int and float", err.Error(),
+		"the error message isn't match")
+	_, err = v.Compare(Value{})
+	as.Equal("value is null", err.Error(), "the error message isn't match")
+}
+
+func Test_Value_CompareNumeric_func(t *testing.T) {
+	as := assert.New(t)
+	i := newIntValue(-1)
+	u := newUintValue(math.MaxUint64)
+	f := newFloatValue(2.5)
+
+	c, err := i.CompareNumeric(u)
+	as.Nil(err, "there is an error in the function")
+	as.Equal(LESS, c, "the comparation is wrong")
+	c, _ = u.CompareNumeric(i)
+	as.Equal(GREAT, c, "the comparation is wrong")
+
+	c, _ = f.CompareNumeric(newIntValue(2))
+	as.Equal(GREAT, c, "the comparation is wrong")
+	c, _ = f.CompareNumeric(newUintValue(3))
+	as.Equal(LESS, c, "the comparation is wrong")
+
+	i = newIntValue(7)
+	c, _ = i.CompareNumeric(newUintValue(7))
+	as.Equal(EQUAL, c, "the comparation is wrong")
+
+	_, err = i.CompareNumeric(newStringValue("7"))
+	as.Equal("the value types are different: int and string", err.Error(),
+		"the error message isn't match")
+}
@@ -0,0 +1,850 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+/*
+RollingWindow struct defines the rows used in each row by the rolling functions. The window
+of a row contains the row and a set of previous rows, defined by one of these ways:
+	- Rows: the number of rows of the window.
+	- Span: the rows whose value in the On column is great than the value of the row
+	  minus Span. The On column must be type int, uint or float and it must be in
+	  ascending order. For example, with a column with unix timestamps, a Span of 3600
+	  defines a window of one hour.
+
+The zero value defines an expanding window: all rows from the first to the current row.
+
+Example:
+	// moving average of 7 rows
+	df, err := df.RollingMean("price", "price_ma7", RollingWindow{Rows: 7})
+
+	// sum of the last hour
+	df, err := df.RollingSum("bytes", "bytes_1h", RollingWindow{On: "time", Span: 3600})
+*/
+type RollingWindow struct {
+	Rows int     // Number of rows of the window.
+	On   string  // Column used to define the window using a span.
+	Span float64 // Span of the values of the On column.
+	// MinPeriods is the min number of not null values needed in the window to calculate
+	// the result. If there are less values the result is null. By default, 1.
+	MinPeriods int
+}
+
+// starts returns the position of the first row of the window of each row of data.
+func (w *RollingWindow) starts(df *DataFrame, data []map[string]Value) ([]int, error) {
+	starts := make([]int, len(data))
+
+	if w.Rows < 0 || w.Span < 0 || w.MinPeriods < 0 {
+		return nil, fmt.Errorf("the rolling window values must be non-negative numbers")
+	}
+
+	if w.On == "" {
+		if w.Span > 0 {
+			return nil, fmt.Errorf("the rolling window span needs the On column")
+		}
+
+		for i := range starts {
+			if w.Rows > 0 && i >= w.Rows {
+				starts[i] = i - w.Rows + 1
+			}
+		}
+
+		return starts, nil
+	}
+
+	if w.Rows > 0 {
+		return nil, fmt.Errorf("the rolling window only can define Rows or Span")
+	}
+
+	if w.Span == 0 {
+		return nil, fmt.Errorf("the rolling window span must be great than 0")
+	}
+
+	col, exists := df.getColumnByName(w.On)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", w.On)
+	}
+
+	if col.ctype != INT && col.ctype != UINT && col.ctype != FLOAT {
+		return nil, fmt.Errorf("column %s is not a number", w.On)
+	}
+
+	on := make([]float64, len(data))
+	for i, row := range data {
+		v := row[w.On]
+		if v.IsNull() {
+			return nil, fmt.Errorf("column %s has null values", w.On)
+		}
+
+		on[i], _ = v.toNumber()
+		if i > 0 && on[i] < on[i-1] {
+			return nil, fmt.Errorf("column %s is not in ascending order", w.On)
+		}
+	}
+
+	// +Inf minus Span is +Inf, so the loop is bounded by the row to keep it in the window.
+	start := 0
+	for i := range on {
+		for start < i && on[start] <= on[i]-w.Span {
+			start++
+		}
+
+		starts[i] = start
+	}
+
+	return starts, nil
+}
+
+// minPeriods returns the min number of values needed to calculate the result.
+func (w *RollingWindow) minPeriods() int {
+	if w.MinPeriods > 0 {
+		return w.MinPeriods
+	}
+
+	return 1
+}
+
+// columnValues returns the values of the colName column of data.
+func columnValues(data []map[string]Value, colName string) []Value {
+	values := make([]Value, len(data))
+	for i, row := range data {
+		values[i] = row[colName]
+	}
+
+	return values
+}
+
+// windowColumn checks if the colName column exists and it has one of the valid types.
+// The name param is the name of the function used in the error message.
+func (df *DataFrame) windowColumn(colName, name string, valid ...columnType) (*column, error) {
+	col, exists := df.getColumnByName(colName)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	for _, ctype := range valid {
+		if col.ctype == ctype {
+			return col, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s function is invalid in column type %s", name, col.ctype)
+}
+
+// windowAccumulator interface is used to calculate the result of the rolling functions.
+// The values are added when they enter in the window and removed when they leave it.
+// The null values are never passed to the accumulator.
+type windowAccumulator interface {
+	add(v Value)
+	remove(v Value)
+	// result returns the result with count values in the window.
+	result(count int) (Value, error)
+}
+
+// floatWindowSum sums the float values of a window. The finite values are added and removed
+// with the Neumaier compensated summation, so the values that leave the window don't keep
+// their rounding error. The NaN and infinite values are counted apart, so they only change the
+// result while they are in the window.
+type floatWindowSum struct {
+	sum, c                      float64
+	finite, nan, posInf, negInf int
+}
+
+func (s *floatWindowSum) add(f float64) {
+	switch {
+	case math.IsNaN(f):
+		s.nan++
+	case math.IsInf(f, 1):
+		s.posInf++
+	case math.IsInf(f, -1):
+		s.negInf++
+	default:
+		s.sum, s.c = neumaierAdd(s.sum, s.c, f)
+		s.finite++
+	}
+}
+
+func (s *floatWindowSum) remove(f float64) {
+	switch {
+	case math.IsNaN(f):
+		s.nan--
+	case math.IsInf(f, 1):
+		s.posInf--
+	case math.IsInf(f, -1):
+		s.negInf--
+	default:
+		s.sum, s.c = neumaierAdd(s.sum, s.c, -f)
+		if s.finite--; s.finite == 0 {
+			s.sum, s.c = 0, 0
+		}
+	}
+}
+
+// value returns the sum of the window: NaN whether it contains a NaN or both infinities,
+// the infinity whether it contains one of them, and the sum of the finite values otherwise.
+func (s *floatWindowSum) value() float64 {
+	switch {
+	case s.nan > 0 || (s.posInf > 0 && s.negInf > 0):
+		return math.NaN()
+	case s.posInf > 0:
+		return math.Inf(1)
+	case s.negInf > 0:
+		return math.Inf(-1)
+	}
+
+	return s.sum + s.c
+}
+
+// sumAccumulator sums the values of a column type int, uint or float. The integers are
+// summed in 128 bits, hi and lo, so the sum of the window is exact although the values that
+// are added before others leave the window overflow the 64 bits.
+type sumAccumulator struct {
+	colName string
+	ctype   columnType
+	hi      uint64
+	lo      uint64
+	f       floatWindowSum
+}
+
+// signBits returns the high 64 bits of the i integer extended to 128 bits.
+func signBits(i int64) uint64 {
+	if i < 0 {
+		return math.MaxUint64
+	}
+
+	return 0
+}
+
+func (a *sumAccumulator) add(v Value) {
+	var carry uint64
+	switch a.ctype {
+	case INT:
+		i, _ := v.Int64()
+		a.lo, carry = bits.Add64(a.lo, uint64(i), 0)
+		a.hi, _ = bits.Add64(a.hi, signBits(i), carry)
+	case UINT:
+		u, _ := v.Uint64()
+		a.lo, carry = bits.Add64(a.lo, u, 0)
+		a.hi += carry
+	default:
+		f, _ := v.Float64()
+		a.f.add(f)
+	}
+}
+
+func (a *sumAccumulator) remove(v Value) {
+	var borrow uint64
+	switch a.ctype {
+	case INT:
+		i, _ := v.Int64()
+		a.lo, borrow = bits.Sub64(a.lo, uint64(i), 0)
+		a.hi, _ = bits.Sub64(a.hi, signBits(i), borrow)
+	case UINT:
+		u, _ := v.Uint64()
+		a.lo, borrow = bits.Sub64(a.lo, u, 0)
+		a.hi -= borrow
+	default:
+		f, _ := v.Float64()
+		a.f.remove(f)
+	}
+}
+
+// result returns the sum of the window. Returns an error if the sum of the integers
+// overflows the column type.
+func (a *sumAccumulator) result(count int) (Value, error) {
+	switch a.ctype {
+	case INT:
+		if a.hi == signBits(int64(a.lo)) {
+			return newIntValue(int64(a.lo)), nil
+		}
+	case UINT:
+		if a.hi == 0 {
+			return newUintValue(a.lo), nil
+		}
+	default:
+		return newFloatValue(a.f.value()), nil
+	}
+
+	return Value{}, fmt.Errorf("the sum of the column %s overflows the type %s",
+		a.colName, a.ctype)
+}
+
+// meanAccumulator calculates the mean of the values, with the sum of the floatWindowSum.
+type meanAccumulator struct {
+	sum floatWindowSum
+}
+
+func (a *meanAccumulator) add(v Value) {
+	x, _ := v.toNumber()
+	a.sum.add(x)
+}
+
+func (a *meanAccumulator) remove(v Value) {
+	x, _ := v.toNumber()
+	a.sum.remove(x)
+}
+
+func (a *meanAccumulator) result(count int) (Value, error) {
+	return newFloatValue(a.sum.value() / float64(count)), nil
+}
+
+// stdRecalculateRatio is the ratio between the squared deviations of a window after and
+// before removing a value, below which the stdAccumulator recalculates its state, because
+// the subtraction has lost most of the significant digits.
+const stdRecalculateRatio = 1e-6
+
+// stdAccumulator calculates the sample standard deviation of the values with the Welford
+// algorithm: the mean and the sum of the squared deviations, m2, are updated when the values
+// enter and leave the window. Removing a value that contains most of the deviation loses
+// precision, so then, and after removing as many values as the window has, the state is
+// recalculated from the values of the window. The NaN and infinite values are counted apart.
+type stdAccumulator struct {
+	// finite values of the window, in the order they enter.
+	window   []float64
+	mean, m2 float64
+	// nonFinite is the number of NaN and infinite values of the window.
+	nonFinite int
+	// removed is the number of values removed since the state was recalculated.
+	removed int
+}
+
+func (a *stdAccumulator) add(v Value) {
+	x, _ := v.toNumber()
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		a.nonFinite++
+		return
+	}
+
+	a.window = append(a.window, x)
+	delta := x - a.mean
+	a.mean += delta / float64(len(a.window))
+	a.m2 += delta * (x - a.mean)
+}
+
+func (a *stdAccumulator) remove(v Value) {
+	x, _ := v.toNumber()
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		a.nonFinite--
+		return
+	}
+
+	// the values leave the window in the same order they enter.
+	a.window = a.window[1:]
+	n := len(a.window)
+	if n == 0 {
+		a.mean, a.m2, a.removed = 0, 0, 0
+		return
+	}
+
+	m2 := a.m2
+	delta := x - a.mean
+	a.mean -= delta / float64(n)
+	a.m2 -= delta * (x - a.mean)
+
+	if a.removed++; a.removed >= n || a.m2 < m2*stdRecalculateRatio {
+		a.recalculate()
+	}
+}
+
+// recalculate calculates the mean and m2 of the window values with the two-pass algorithm.
+func (a *stdAccumulator) recalculate() {
+	sum := floatWindowSum{}
+	for _, x := range a.window {
+		sum.add(x)
+	}
+
+	a.mean, a.m2, a.removed = sum.value()/float64(len(a.window)), 0, 0
+	for _, x := range a.window {
+		a.m2 += (x - a.mean) * (x - a.mean)
+	}
+}
+
+func (a *stdAccumulator) result(count int) (Value, error) {
+	if count < 2 {
+		return Value{}, nil
+	}
+
+	if a.nonFinite > 0 {
+		return newFloatValue(math.NaN()), nil
+	}
+
+	return newFloatValue(math.Sqrt(math.Max(a.m2, 0) / float64(count-1))), nil
+}
+
+// rolling calculates the acc accumulator in the window of each row and returns a new
+// DataFrame with the results in the newColName column, type ctype.
+func (df *DataFrame) rolling(
+	colName, newColName string, w RollingWindow, acc windowAccumulator, ctype columnType,
+) (*DataFrame, error) {
+	data := df.snapshot()
+	starts, err := w.starts(df, data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+	start, count := 0, 0
+
+	for i, v := range values {
+		if !v.IsNull() {
+			acc.add(v)
+			count++
+		}
+
+		for ; start < starts[i]; start++ {
+			if !values[start].IsNull() {
+				acc.remove(values[start])
+				count--
+			}
+		}
+
+		if count >= w.minPeriods() {
+			if results[i], err = acc.result(count); err != nil {
+				return nil, fmt.Errorf("error in the row %d: %s", i, err.Error())
+			}
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, ctype, results)
+}
+
+// RollingSum returns a new DataFrame with the newColName column, that contains the sum of
+// the colName column values in the window of each row. The column must be type int, uint or
+// float, and the new column has the same type. The null values are ignored. In the float
+// columns, the sum is NaN while the window contains a NaN value. Returns an error if the sum
+// of a window overflows the int or uint type.
+func (df *DataFrame) RollingSum(colName, newColName string, w RollingWindow) (*DataFrame, error) {
+	col, err := df.windowColumn(colName, "RollingSum", INT, UINT, FLOAT)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &sumAccumulator{colName: colName, ctype: col.ctype}
+	return df.rolling(colName, newColName, w, acc, col.ctype)
+}
+
+// RollingMean returns a new DataFrame with the newColName column, type float, that contains
+// the arithmetic mean of the colName column values in the window of each row. The column
+// must be type int, uint or float. The null values are ignored, and the mean is NaN while
+// the window contains a NaN value.
+func (df *DataFrame) RollingMean(
+	colName, newColName string, w RollingWindow,
+) (*DataFrame, error) {
+	if _, err := df.windowColumn(colName, "RollingMean", INT, UINT, FLOAT); err != nil {
+		return nil, err
+	}
+
+	return df.rolling(colName, newColName, w, &meanAccumulator{}, FLOAT)
+}
+
+// RollingStd returns a new DataFrame with the newColName column, type float, that contains
+// the sample standard deviation of the colName column values in the window of each row.
+// The column must be type int, uint or float. The null values are ignored, and the result
+// is null in the windows with less than 2 values. The result is NaN while the window contains
+// a NaN or infinite value.
+func (df *DataFrame) RollingStd(colName, newColName string, w RollingWindow) (*DataFrame, error) {
+	if _, err := df.windowColumn(colName, "RollingStd", INT, UINT, FLOAT); err != nil {
+		return nil, err
+	}
+
+	return df.rolling(colName, newColName, w, &stdAccumulator{}, FLOAT)
+}
+
+// rollingMinOrMax calculates the min or the max, depending of the isMin param, of the
+// window of each row. It uses a monotonic queue, so each value is compared a few times.
+func (df *DataFrame) rollingMinOrMax(
+	isMin bool, colName, newColName string, w RollingWindow,
+) (*DataFrame, error) {
+	name := "RollingMax"
+	if isMin {
+		name = "RollingMin"
+	}
+
+	col, err := df.windowColumn(colName, name, INT, UINT, FLOAT, STRING, CATEGORY)
+	if err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	starts, err := w.starts(df, data)
+	if err != nil {
+		return nil, err
+	}
+
+	compare := columnComparer(col.ctype)
+	discard := LESS // the queue discards the values less than the new value.
+	if isMin {
+		discard = GREAT
+	}
+
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+	queue := []int{}
+	count := 0
+
+	for i, v := range values {
+		if !v.IsNull() {
+			for len(queue) > 0 {
+				if comp, _ := compare(values[queue[len(queue)-1]], v); comp != discard {
+					break
+				}
+				queue = queue[:len(queue)-1]
+			}
+
+			queue = append(queue, i)
+			count++
+		}
+
+		if i > 0 {
+			for j := starts[i-1]; j < starts[i]; j++ {
+				if !values[j].IsNull() {
+					count--
+				}
+			}
+		}
+
+		for len(queue) > 0 && queue[0] < starts[i] {
+			queue = queue[1:]
+		}
+
+		if count >= w.minPeriods() && len(queue) > 0 {
+			results[i] = values[queue[0]]
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, col.ctype, results)
+}
+
+// RollingMin returns a new DataFrame with the newColName column, that contains the min of
+// the colName column values in the window of each row. The column must be type int, uint,
+// float or string, and the new column has the same type. The null values are ignored.
+func (df *DataFrame) RollingMin(colName, newColName string, w RollingWindow) (*DataFrame, error) {
+	return df.rollingMinOrMax(true, colName, newColName, w)
+}
+
+// RollingMax returns a new DataFrame with the newColName column, that contains the max of
+// the colName column values in the window of each row. The column must be type int, uint,
+// float or string, and the new column has the same type. The null values are ignored.
+func (df *DataFrame) RollingMax(colName, newColName string, w RollingWindow) (*DataFrame, error) {
+	return df.rollingMinOrMax(false, colName, newColName, w)
+}
+
+// CumSum returns a new DataFrame with the newColName column, that contains the cumulative
+// sum of the colName column. It is a RollingSum with an expanding window.
+func (df *DataFrame) CumSum(colName, newColName string) (*DataFrame, error) {
+	return df.RollingSum(colName, newColName, RollingWindow{})
+}
+
+// CumMax returns a new DataFrame with the newColName column, that contains the cumulative
+// max of the colName column. It is a RollingMax with an expanding window.
+func (df *DataFrame) CumMax(colName, newColName string) (*DataFrame, error) {
+	return df.RollingMax(colName, newColName, RollingWindow{})
+}
+
+// CumMin returns a new DataFrame with the newColName column, that contains the cumulative
+// min of the colName column. It is a RollingMin with an expanding window.
+func (df *DataFrame) CumMin(colName, newColName string) (*DataFrame, error) {
+	return df.RollingMin(colName, newColName, RollingWindow{})
+}
+
+// CumProd returns a new DataFrame with the newColName column, that contains the cumulative
+// product of the colName column. The column must be type int, uint or float, and the new
+// column has the same type. The null values are ignored. Returns an error if the product
+// overflows the int or uint type.
+func (df *DataFrame) CumProd(colName, newColName string) (*DataFrame, error) {
+	col, err := df.windowColumn(colName, "CumProd", INT, UINT, FLOAT)
+	if err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+	var (
+		i     int64   = 1
+		u     uint64  = 1
+		f     float64 = 1
+		found bool
+		// overflow is true whether the product overflows the column type.
+		overflow bool
+	)
+
+	for indx, v := range values {
+		if !v.IsNull() {
+			found = true
+			switch col.ctype {
+			case INT:
+				n, _ := v.Int64()
+				i, overflow = mulInt64(i, n)
+			case UINT:
+				n, _ := v.Uint64()
+				var hi uint64
+				hi, u = bits.Mul64(u, n)
+				overflow = hi != 0
+			case FLOAT:
+				n, _ := v.Float64()
+				f *= n
+			}
+
+			if overflow {
+				return nil, fmt.Errorf("error in the row %d: the product of the column %s "+
+					"overflows the type %s", indx, colName, col.ctype)
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		switch col.ctype {
+		case INT:
+			results[indx] = newIntValue(i)
+		case UINT:
+			results[indx] = newUintValue(u)
+		case FLOAT:
+			results[indx] = newFloatValue(f)
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, col.ctype, results)
+}
+
+// Shift returns a new DataFrame with the newColName column, that contains the value of the
+// colName column n rows before. If n is negative, it contains the value -n rows after.
+// The rows without a previous, or next, value are null.
+func (df *DataFrame) Shift(colName, newColName string, n int) (*DataFrame, error) {
+	col, exists := df.getColumnByName(colName)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	data := df.snapshot()
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+
+	for i := range values {
+		if j := i - n; j >= 0 && j < len(values) {
+			results[i] = values[j]
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, col.ctype, results)
+}
+
+// Diff returns a new DataFrame with the newColName column, that contains the difference
+// between the value of the colName column and the value n rows before. The column must be
+// type int, uint, float or complex. The new column has the same type, except with the uint
+// columns, whose difference is type int. The result is null whether some of the values is
+// null or the row hasn't a row n positions before. Returns an error if the difference
+// overflows the int type.
+func (df *DataFrame) Diff(colName, newColName string, n int) (*DataFrame, error) {
+	col, err := df.windowColumn(colName, "Diff", INT, UINT, FLOAT, COMPLEX)
+	if err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+	ctype := col.ctype
+	if ctype == UINT {
+		ctype = INT
+	}
+
+	for i, v := range values {
+		j := i - n
+		if j < 0 || j >= len(values) || v.IsNull() || values[j].IsNull() {
+			continue
+		}
+
+		prev := values[j]
+		overflow := false
+		switch col.ctype {
+		case INT:
+			a, _ := v.Int64()
+			b, _ := prev.Int64()
+			var d int64
+			d, overflow = subInt64(a, b)
+			results[i] = newIntValue(d)
+		case UINT:
+			a, _ := v.Uint64()
+			b, _ := prev.Uint64()
+			// the difference fits in an int whether its sign is the sign of the int64.
+			d, borrow := bits.Sub64(a, b, 0)
+			overflow = (borrow == 1) != (int64(d) < 0)
+			results[i] = newIntValue(int64(d))
+		case FLOAT:
+			a, _ := v.Float64()
+			b, _ := prev.Float64()
+			results[i] = newFloatValue(a - b)
+		case COMPLEX:
+			a, _ := v.Complex128()
+			b, _ := prev.Complex128()
+			results[i] = newComplexValue(a - b)
+		}
+
+		if overflow {
+			return nil, fmt.Errorf("error in the row %d: the difference of the column %s "+
+				"overflows the type %s", i, colName, ctype)
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, ctype, results)
+}
+
+// PctChange returns a new DataFrame with the newColName column, type float, that contains the
+// relative change between the value of the colName column and the value n rows before.
+// The column must be type int, uint or float. The result is null whether some of the values
+// is null or the row hasn't a row n positions before.
+func (df *DataFrame) PctChange(colName, newColName string, n int) (*DataFrame, error) {
+	if _, err := df.windowColumn(colName, "PctChange", INT, UINT, FLOAT); err != nil {
+		return nil, err
+	}
+
+	data := df.snapshot()
+	values := columnValues(data, colName)
+	results := make([]Value, len(values))
+
+	for i, v := range values {
+		j := i - n
+		if j < 0 || j >= len(values) || v.IsNull() || values[j].IsNull() {
+			continue
+		}
+
+		a, _ := v.toNumber()
+		b, _ := values[j].toNumber()
+		results[i] = newFloatValue((a - b) / b)
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, FLOAT, results)
+}
+
+// partitions splits the data rows in partitions, using the values of the partitionBy
+// columns. It returns the positions of the rows of each partition, in the current order.
+func (df *DataFrame) partitions(
+	data []map[string]Value, partitionBy []string,
+) ([][]int, error) {
+	for _, colName := range partitionBy {
+		if _, exists := df.getColumnByName(colName); !exists {
+			return nil, fmt.Errorf("column %s not found", colName)
+		}
+	}
+
+	partitions := [][]int{}
+	index := map[string]int{}
+	values := make([]Value, len(partitionBy))
+
+	for i, row := range data {
+		for j, colName := range partitionBy {
+			values[j] = row[colName]
+		}
+
+		key := valuesKey(values)
+		p, exists := index[key]
+		if !exists {
+			p = len(partitions)
+			index[key] = p
+			partitions = append(partitions, []int{})
+		}
+
+		partitions[p] = append(partitions[p], i)
+	}
+
+	return partitions, nil
+}
+
+// RowNumber returns a new DataFrame with the newColName column, type int, that contains the
+// number of each row, starting in 1, inside of its partition. The partitions are defined by
+// the values of the partitionBy columns. Without partitionBy columns all rows are in the
+// same partition.
+func (df *DataFrame) RowNumber(newColName string, partitionBy ...string) (*DataFrame, error) {
+	data := df.snapshot()
+	partitions, err := df.partitions(data, partitionBy)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Value, len(data))
+	for _, partition := range partitions {
+		for n, i := range partition {
+			results[i] = newIntValue(int64(n + 1))
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, INT, results)
+}
+
+// rank calculates the rank of the rows inside of its partition. Whether dense is true, the
+// ranks haven't gaps between the equal values.
+func (df *DataFrame) rank(
+	dense bool, colName, newColName string, order orderType, partitionBy []string,
+) (*DataFrame, error) {
+	col, exists := df.getColumnByName(colName)
+	if !exists {
+		return nil, fmt.Errorf("column %s not found", colName)
+	}
+
+	data := df.snapshot()
+	partitions, err := df.partitions(data, partitionBy)
+	if err != nil {
+		return nil, err
+	}
+
+	ocol := internalOrderColumn{col, order, OrderOptions{}}
+	f := ocol.valueComparer()
+	values := columnValues(data, colName)
+	results := make([]Value, len(data))
+
+	for _, partition := range partitions {
+		sort.SliceStable(partition, func(i, j int) bool {
+			comp, _ := ocol.compare(f, values[partition[i]], values[partition[j]])
+			return comp == LESS
+		})
+
+		rank := int64(0)
+		for n, i := range partition {
+			if values[i].IsNull() {
+				break
+			}
+
+			if n == 0 {
+				rank = 1
+			} else if comp, _ := f(values[partition[n-1]], values[i]); comp != EQUAL {
+				if dense {
+					rank++
+				} else {
+					rank = int64(n + 1)
+				}
+			}
+
+			results[i] = newIntValue(rank)
+		}
+	}
+
+	return df.newDataFrameWithColumn(data, newColName, INT, results)
+}
+
+// Rank returns a new DataFrame with the newColName column, type int, that contains the rank
+// of the colName value inside of its partition, in the order defined by the order param.
+// The rows with equal values have the same rank, and the next rank has a gap (1, 2, 2, 4).
+// The partitions are defined by the values of the partitionBy columns. The null values
+// haven't rank.
+func (df *DataFrame) Rank(
+	colName, newColName string, order orderType, partitionBy ...string,
+) (*DataFrame, error) {
+	return df.rank(false, colName, newColName, order, partitionBy)
+}
+
+// DenseRank returns a new DataFrame with the newColName column, type int, that contains the
+// rank of the colName value inside of its partition, like the Rank method, but without gaps
+// between the ranks (1, 2, 2, 3).
+func (df *DataFrame) DenseRank(
+	colName, newColName string, order orderType, partitionBy ...string,
+) (*DataFrame, error) {
+	return df.rank(true, colName, newColName, order, partitionBy)
+}
@@ -0,0 +1,431 @@
+package dataframe
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+type windowData struct {
+	T int     `colName:"t"`
+	I *int    `colName:"i"`
+	F float64 `colName:"f"`
+	S string  `colName:"s"`
+	G string  `colName:"g"`
+}
+
+// makeWindowDataFrame makes the DataFrame used in the window tests.
+func makeWindowDataFrame(t *testing.T) *DataFrame {
+	n := []int{1, 2, 3, 4, 5}
+	data := []windowData{
+		{0, &n[2], 1, "c", "x"},
+		{10, &n[0], 2, "a", "y"},
+		{20, nil, 4, "e", "x"},
+		{35, &n[4], 8, "b", "y"},
+		{40, &n[1], 16, "d", "x"},
+	}
+
+	return makeDataFrame(data, t)
+}
+
+// windowResults returns the values of the colName column as strings.
+func windowResults(df *DataFrame, colName string) []string {
+	results := []string{}
+	values, _ := df.Column(colName)
+	for _, v := range values {
+		results = append(results, v.String())
+	}
+
+	return results
+}
+
+func Test_RollingWindow_starts(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeWindowDataFrame(t); df == nil {
+		return
+	}
+
+	data := df.snapshot()
+
+	starts, err := (&RollingWindow{}).starts(df, data)
+	as.Nil(err, "there is an error in the window")
+	as.Equal([]int{0, 0, 0, 0, 0}, starts, "the expanding window is wrong")
+
+	starts, err = (&RollingWindow{Rows: 2}).starts(df, data)
+	as.Nil(err, "there is an error in the window")
+	as.Equal([]int{0, 0, 1, 2, 3}, starts, "the rows window is wrong")
+
+	starts, err = (&RollingWindow{On: "t", Span: 15}).starts(df, data)
+	as.Nil(err, "there is an error in the window")
+	as.Equal([]int{0, 0, 1, 3, 3}, starts, "the span window is wrong")
+
+	// the infinite values only contain their row.
+	inf, _ := NewDataFrameFromColumns(map[string]interface{}{
+		"t": []float64{0, 1, math.Inf(1), math.Inf(1)},
+	}, "t")
+
+	starts, err = (&RollingWindow{On: "t", Span: 2}).starts(inf, inf.snapshot())
+	as.Nil(err, "there is an error in the window")
+	as.Equal([]int{0, 0, 2, 3}, starts, "the span window is wrong")
+
+	// errors
+	_, err = (&RollingWindow{Rows: -1}).starts(df, data)
+	as.Equal("the rolling window values must be non-negative numbers", err.Error(),
+		"the error message doesn't match")
+	_, err = (&RollingWindow{Span: 1}).starts(df, data)
+	as.Equal("the rolling window span needs the On column", err.Error(),
+		"the error message doesn't match")
+	_, err = (&RollingWindow{Rows: 1, On: "t", Span: 1}).starts(df, data)
+	as.Equal("the rolling window only can define Rows or Span", err.Error(),
+		"the error message doesn't match")
+	_, err = (&RollingWindow{On: "t"}).starts(df, data)
+	as.Equal("the rolling window span must be great than 0", err.Error(),
+		"the error message doesn't match")
+	_, err = (&RollingWindow{On: "x", Span: 1}).starts(df, data)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+	_, err = (&RollingWindow{On: "s", Span: 1}).starts(df, data)
+	as.Equal("column s is not a number", err.Error(), "the error message doesn't match")
+
+	df.Order(OrderColumn{"t", DESC})
+	_, err = (&RollingWindow{On: "t", Span: 1}).starts(df, df.snapshot())
+	as.Equal("column t is not in ascending order", err.Error(),
+		"the error message doesn't match")
+
+	df.OrderWithOptions(OrderColumn{"i", ASC}.WithOptions(OrderOptions{Nulls: NULLS_FIRST}))
+	_, err = (&RollingWindow{On: "i", Span: 1}).starts(df, df.snapshot())
+	as.Equal("column i has null values", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Rolling_funcs(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeWindowDataFrame(t); df == nil {
+		return
+	}
+
+	w := RollingWindow{Rows: 2}
+
+	result, err := df.RollingSum("i", "sum", w)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"3", "4", "1", "5", "7"}, windowResults(result, "sum"),
+		"the rolling sum is wrong")
+	as.Equal([]string{"t", "i", "f", "s", "g", "sum"}, result.Headers(),
+		"the headers are wrong")
+
+	result, _ = df.RollingSum("f", "sum", RollingWindow{On: "t", Span: 15})
+	as.Equal([]string{"1", "3", "6", "8", "24"}, windowResults(result, "sum"),
+		"the rolling sum is wrong")
+
+	result, _ = df.RollingMean("f", "mean", RollingWindow{Rows: 3})
+	as.Equal([]string{"1", "1.5", "2.3333333333333335", "4.666666666666667", "9.333333333333334"},
+		windowResults(result, "mean"), "the rolling mean is wrong")
+
+	result, _ = df.RollingMean("i", "mean", RollingWindow{Rows: 2, MinPeriods: 2})
+	as.Equal([]string{"", "2", "", "", "3.5"}, windowResults(result, "mean"),
+		"the rolling mean is wrong")
+
+	result, _ = df.RollingStd("f", "std", RollingWindow{Rows: 2})
+	values, _ := result.ColumnAsFloat("f")
+	stds, _ := result.Column("std")
+	as.True(stds[0].IsNull(), "the std of one value is null")
+	for i := 1; i < len(values); i++ {
+		std, _ := stds[i].Float64()
+		as.InDelta(math.Abs(values[i]-values[i-1])/math.Sqrt2, std, 1e-12,
+			"the rolling std is wrong")
+	}
+
+	result, _ = df.RollingMin("s", "min", RollingWindow{Rows: 3})
+	as.Equal([]string{"c", "a", "a", "a", "b"}, windowResults(result, "min"),
+		"the rolling min is wrong")
+
+	result, _ = df.RollingMax("i", "max", RollingWindow{Rows: 2})
+	as.Equal([]string{"3", "3", "1", "5", "5"}, windowResults(result, "max"),
+		"the rolling max is wrong")
+
+	// errors
+	_, err = df.RollingSum("s", "sum", w)
+	as.Equal("RollingSum function is invalid in column type string", err.Error(),
+		"the error message doesn't match")
+	_, err = df.RollingMean("x", "mean", w)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+	_, err = df.RollingMax("i", "f", w)
+	as.Equal("the column f is duplicated", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_Rolling_Float_Precision(t *testing.T) {
+	as := assert.New(t)
+	nan := math.NaN()
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"big": []float64{1e16, 1, 1, 1, 1, 1},
+		"nan": []float64{1, nan, 1, 1, 1, 1},
+		"inf": []float64{1, math.Inf(1), 2, math.Inf(-1), 3, 3},
+	}, "big", "nan", "inf")
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	w := RollingWindow{Rows: 2}
+
+	// the big value doesn't keep its rounding error after leaving the window.
+	result, _ := df.RollingSum("big", "sum", w)
+	as.Equal([]string{"1e+16", "1e+16", "2", "2", "2", "2"},
+		windowResults(result, "sum"), "the rolling sum is wrong")
+
+	result, _ = df.RollingMean("big", "mean", w)
+	as.Equal([]string{"1e+16", "5e+15", "1", "1", "1", "1"},
+		windowResults(result, "mean"), "the rolling mean is wrong")
+
+	result, _ = df.RollingStd("big", "std", w)
+	as.Equal([]string{"", "7.071067811865475e+15", "0", "0", "0", "0"},
+		windowResults(result, "std"), "the rolling std is wrong")
+
+	// the NaN values only change the result while they are in the window.
+	result, _ = df.RollingSum("nan", "sum", w)
+	as.Equal([]string{"1", "NaN", "NaN", "2", "2", "2"}, windowResults(result, "sum"),
+		"the rolling sum is wrong")
+
+	result, _ = df.RollingMean("nan", "mean", w)
+	as.Equal([]string{"1", "NaN", "NaN", "1", "1", "1"}, windowResults(result, "mean"),
+		"the rolling mean is wrong")
+
+	result, _ = df.RollingStd("nan", "std", w)
+	as.Equal([]string{"", "NaN", "NaN", "0", "0", "0"}, windowResults(result, "std"),
+		"the rolling std is wrong")
+
+	// the infinite values.
+	result, _ = df.RollingSum("inf", "sum", w)
+	as.Equal([]string{"1", "+Inf", "+Inf", "-Inf", "-Inf", "6"}, windowResults(result, "sum"),
+		"the rolling sum is wrong")
+
+	result, _ = df.RollingSum("inf", "sum", RollingWindow{Rows: 3})
+	as.Equal([]string{"1", "+Inf", "+Inf", "NaN", "-Inf", "-Inf"},
+		windowResults(result, "sum"), "the rolling sum is wrong")
+}
+
+func Test_stdAccumulator_func(t *testing.T) {
+	as := assert.New(t)
+
+	// values with a big mean and a small deviation, and some outliers.
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = 1e6 + 10*math.Sin(float64(i))
+		if i%97 == 0 {
+			values[i] = 1e12
+		}
+	}
+
+	// stdOf calculates the deviation of the values with the two-pass algorithm.
+	stdOf := func(values []float64) float64 {
+		mean := 0.0
+		for _, x := range values {
+			mean += x / float64(len(values))
+		}
+
+		m2 := 0.0
+		for _, x := range values {
+			m2 += (x - mean) * (x - mean)
+		}
+
+		return math.Sqrt(m2 / float64(len(values)-1))
+	}
+
+	for _, rows := range []int{3, 50, 0} {
+		acc := stdAccumulator{}
+		start := 0
+		for i, x := range values {
+			acc.add(newFloatValue(x))
+			if rows > 0 && i-start >= rows {
+				acc.remove(newFloatValue(values[start]))
+				start++
+			}
+
+			result, _ := acc.result(i - start + 1)
+			if i == start {
+				as.True(result.IsNull(), "the deviation of a value is null")
+				continue
+			}
+
+			std, _ := result.Float64()
+			expected := stdOf(values[start : i+1])
+			as.InDelta(expected, std, 1e-6*expected+1e-9,
+				"the deviation of the row %d with %d rows is wrong", i, rows)
+		}
+	}
+}
+
+func Test_DataFrame_Rolling_Int_Overflow(t *testing.T) {
+	as := assert.New(t)
+
+	df, err := NewDataFrameFromColumns(map[string]interface{}{
+		"i": []int64{math.MaxInt64, -5, 10, math.MinInt64, 1},
+		"u": []uint64{math.MaxUint64, 0, 1, 2, 3},
+	}, "i", "u")
+
+	if err != nil {
+		as.FailNow("error creating DataFrame", "error: %s", err.Error())
+	}
+
+	// the sum of the windows is exact, although the sum before removing the values overflows.
+	result, err := df.RollingSum("i", "sum", RollingWindow{Rows: 2})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"9223372036854775807", "9223372036854775802", "5",
+		"-9223372036854775798", "-9223372036854775807"}, windowResults(result, "sum"),
+		"the rolling sum is wrong")
+
+	result, err = df.RollingSum("u", "sum", RollingWindow{Rows: 2, MinPeriods: 2})
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"", "18446744073709551615", "1", "3", "5"},
+		windowResults(result, "sum"), "the rolling sum is wrong")
+
+	// errors.
+	_, err = df.RollingSum("i", "sum", RollingWindow{Rows: 3})
+	as.EqualError(err, "error in the row 2: the sum of the column i overflows the type int",
+		"the error is wrong")
+
+	_, err = df.CumSum("u", "sum")
+	as.EqualError(err, "error in the row 2: the sum of the column u overflows the type uint",
+		"the error is wrong")
+
+	_, err = df.CumProd("i", "prod")
+	as.EqualError(err, "error in the row 1: the product of the column i overflows the type "+
+		"int", "the error is wrong")
+
+	_, err = df.CumProd("u", "prod")
+	as.Nil(err, "the product with 0 doesn't overflow")
+
+	_, err = df.Diff("i", "diff", 1)
+	as.EqualError(err, "error in the row 1: the difference of the column i overflows the type "+
+		"int", "the error is wrong")
+
+	_, err = df.Diff("u", "diff", 1)
+	as.EqualError(err, "error in the row 1: the difference of the column u overflows the type "+
+		"int", "the error is wrong")
+
+	// the difference of the uints is an int.
+	df, _ = NewDataFrameFromColumns(map[string]interface{}{
+		"u": []uint64{1 << 63, 0, 1<<63 - 1},
+	})
+
+	result, err = df.Diff("u", "diff", 1)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"", "-9223372036854775808", "9223372036854775807"},
+		windowResults(result, "diff"), "the diff is wrong")
+
+	_, err = df.Diff("u", "diff", -1)
+	as.EqualError(err, "error in the row 0: the difference of the column u overflows the type "+
+		"int", "the error is wrong")
+}
+
+func Test_DataFrame_Cumulative_funcs(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeWindowDataFrame(t); df == nil {
+		return
+	}
+
+	result, err := df.CumSum("i", "c")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"3", "4", "4", "9", "11"}, windowResults(result, "c"),
+		"the cumulative sum is wrong")
+
+	result, _ = df.CumProd("f", "c")
+	as.Equal([]string{"1", "2", "8", "64", "1024"}, windowResults(result, "c"),
+		"the cumulative product is wrong")
+
+	result, _ = df.CumMax("s", "c")
+	as.Equal([]string{"c", "c", "e", "e", "e"}, windowResults(result, "c"),
+		"the cumulative max is wrong")
+
+	result, _ = df.CumMin("i", "c")
+	as.Equal([]string{"3", "1", "1", "1", "1"}, windowResults(result, "c"),
+		"the cumulative min is wrong")
+
+	// the original DataFrame isn't modified.
+	as.Equal([]string{"t", "i", "f", "s", "g"}, df.Headers(), "the headers are wrong")
+}
+
+func Test_DataFrame_Shift_Diff_PctChange(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+
+	if df = makeWindowDataFrame(t); df == nil {
+		return
+	}
+
+	result, err := df.Shift("s", "c", 1)
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"", "c", "a", "e", "b"}, windowResults(result, "c"),
+		"the shift is wrong")
+
+	result, _ = df.Shift("s", "c", -2)
+	as.Equal([]string{"e", "b", "d", "", ""}, windowResults(result, "c"),
+		"the shift is wrong")
+
+	result, _ = df.Diff("i", "c", 1)
+	as.Equal([]string{"", "-2", "", "", "-3"}, windowResults(result, "c"),
+		"the diff is wrong")
+
+	result, _ = df.Diff("t", "c", 2)
+	as.Equal([]string{"", "", "20", "25", "20"}, windowResults(result, "c"),
+		"the diff is wrong")
+
+	result, _ = df.PctChange("f", "c", 1)
+	as.Equal([]string{"", "1", "1", "1", "1"}, windowResults(result, "c"),
+		"the pct change is wrong")
+
+	_, err = df.Diff("s", "c", 1)
+	as.Equal("Diff function is invalid in column type string", err.Error(),
+		"the error message doesn't match")
+	_, err = df.Shift("x", "c", 1)
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+}
+
+func Test_DataFrame_RowNumber_Rank(t *testing.T) {
+	var df *DataFrame
+	as := assert.New(t)
+	data := []struct {
+		G string `colName:"g"`
+		V int    `colName:"v"`
+	}{
+		{"a", 3}, {"b", 1}, {"a", 1}, {"a", 3}, {"b", 2}, {"a", 2},
+	}
+
+	if df = makeDataFrame(data, t); df == nil {
+		return
+	}
+
+	result, err := df.RowNumber("n")
+	as.Nil(err, "there is an error in the function")
+	as.Equal([]string{"1", "2", "3", "4", "5", "6"}, windowResults(result, "n"),
+		"the row number is wrong")
+
+	result, _ = df.RowNumber("n", "g")
+	as.Equal([]string{"1", "1", "2", "3", "2", "4"}, windowResults(result, "n"),
+		"the row number is wrong")
+
+	result, _ = df.Rank("v", "r", ASC)
+	as.Equal([]string{"5", "1", "1", "5", "3", "3"}, windowResults(result, "r"),
+		"the rank is wrong")
+
+	result, _ = df.Rank("v", "r", DESC, "g")
+	as.Equal([]string{"1", "2", "4", "1", "1", "3"}, windowResults(result, "r"),
+		"the rank is wrong")
+
+	result, _ = df.DenseRank("v", "r", ASC)
+	as.Equal([]string{"3", "1", "1", "3", "2", "2"}, windowResults(result, "r"),
+		"the dense rank is wrong")
+
+	_, err = df.RowNumber("n", "x")
+	as.Equal("column x not found", err.Error(), "the error message doesn't match")
+
+	// nulls haven't rank.
+	df = makeWindowDataFrame(t)
+	result, _ = df.Rank("i", "r", ASC)
+	as.Equal([]string{"3", "1", "", "4", "2"}, windowResults(result, "r"),
+		"the rank is wrong")
+}